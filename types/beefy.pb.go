@@ -8,6 +8,7 @@ import (
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	golang_proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/protobuf/types/known/durationpb"
 	_ "google.golang.org/protobuf/types/known/timestamppb"
 	math "math"
 	time "time"
@@ -54,6 +55,94 @@ func (RelayChain) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_71b13aa2f4351d30, []int{0}
 }
 
+// Source of the commitment root of the consensus states
+type RootSource int32
+
+const (
+	// parachain state root, the ibc state is proven through the child trie of pallet-ibc
+	RootSource_STATE_ROOT RootSource = 0
+	// root of the ibc child trie, deposited by pallet-ibc in the /IBC consensus digest of the header
+	RootSource_IBC_DIGEST RootSource = 1
+)
+
+var RootSource_name = map[int32]string{
+	0: "STATE_ROOT",
+	1: "IBC_DIGEST",
+}
+
+var RootSource_value = map[string]int32{
+	"STATE_ROOT": 0,
+	"IBC_DIGEST": 1,
+}
+
+func (x RootSource) String() string {
+	return proto.EnumName(RootSource_name, int32(x))
+}
+
+func (RootSource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_71b13aa2f4351d30, []int{1}
+}
+
+// Signature scheme of the commitments signed by the beefy authorities
+type SignatureType int32
+
+const (
+	// secp256k1 ECDSA signatures, the authority root commits to the keccak256 hash of the
+	// ethereum address of every authority
+	SignatureType_ECDSA SignatureType = 0
+	// TinyBLS381 signatures in G1 aggregated in the signed commitment, the authority root commits to
+	// the keccak256 hash of the compressed G2 public key of every authority
+	SignatureType_BLS12_381 SignatureType = 1
+)
+
+var SignatureType_name = map[int32]string{
+	0: "ECDSA",
+	1: "BLS12_381",
+}
+
+var SignatureType_value = map[string]int32{
+	"ECDSA":     0,
+	"BLS12_381": 1,
+}
+
+func (x SignatureType) String() string {
+	return proto.EnumName(SignatureType_name, int32(x))
+}
+
+func (SignatureType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_71b13aa2f4351d30, []int{2}
+}
+
+// Kind of misbehaviour proven by the headers of a Misbehaviour
+type MisbehaviourType int32
+
+const (
+	// two commitments of the same authority set for the same block, but to different mmr roots,
+	// proven by the signed commitments of the headers
+	MisbehaviourType_EQUIVOCATION MisbehaviourType = 0
+	// two different parachain headers of the same parachain at the same height, proven into the mmr
+	// by the parachain updates of the headers
+	MisbehaviourType_PARACHAIN_FORK MisbehaviourType = 1
+)
+
+var MisbehaviourType_name = map[int32]string{
+	0: "EQUIVOCATION",
+	1: "PARACHAIN_FORK",
+}
+
+var MisbehaviourType_value = map[string]int32{
+	"EQUIVOCATION":   0,
+	"PARACHAIN_FORK": 1,
+}
+
+func (x MisbehaviourType) String() string {
+	return proto.EnumName(MisbehaviourType_name, int32(x))
+}
+
+func (MisbehaviourType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_71b13aa2f4351d30, []int{3}
+}
+
 // ClientState from Beefy tracks the current validator set, latest height,
 // and a possible frozen height.
 type ClientState struct {
@@ -76,6 +165,30 @@ type ClientState struct {
 	Authority *BeefyAuthoritySet `protobuf:"bytes,8,opt,name=authority,proto3" json:"authority,omitempty"`
 	// authorities for the next round
 	NextAuthoritySet *BeefyAuthoritySet `protobuf:"bytes,9,opt,name=next_authority_set,json=nextAuthoritySet,proto3" json:"next_authority_set,omitempty"`
+	// duration after which a consensus state is pruned, a zero duration keeps consensus states forever.
+	ConsensusStateRetentionPeriod time.Duration `protobuf:"bytes,10,opt,name=consensus_state_retention_period,json=consensusStateRetentionPeriod,proto3,stdduration" json:"consensus_state_retention_period"`
+	// maximum number of consensus states kept in the client store, zero means unbounded.
+	MaxConsensusStates uint32 `protobuf:"varint,11,opt,name=max_consensus_states,json=maxConsensusStates,proto3" json:"max_consensus_states,omitempty"`
+	// duration of the period since the latest consensus state during which the client can be trusted,
+	// a zero duration disables expiry.
+	TrustingPeriod time.Duration `protobuf:"bytes,12,opt,name=trusting_period,json=trustingPeriod,proto3,stdduration" json:"trusting_period"`
+	// parachains tracked by the client alongside para_id, whose consensus states are
+	// stored with their para id as the revision number.
+	AdditionalParachains []AdditionalParachain `protobuf:"bytes,13,rep,name=additional_parachains,json=additionalParachains,proto3" json:"additional_parachains"`
+	// source of the commitment root of the consensus states
+	RootSource RootSource `protobuf:"varint,14,opt,name=root_source,json=rootSource,proto3,enum=beefy.v1.RootSource" json:"root_source,omitempty"`
+	// signature scheme of the commitments signed by the authorities
+	SignatureType SignatureType `protobuf:"varint,15,opt,name=signature_type,json=signatureType,proto3,enum=beefy.v1.SignatureType" json:"signature_type,omitempty"`
+	// fraction of an authority set that the signatures of a final commitment must exceed,
+	// a zero supermajority is the two thirds of the beefy protocol.
+	Supermajority Supermajority `protobuf:"bytes,16,opt,name=supermajority,proto3" json:"supermajority"`
+	// commitment prefix of the ibc state of para_id. Proofs are only verified against the consensus states
+	// of the parachain whose commitment prefix they are under, an empty prefix matches any prefix when the
+	// client tracks a single parachain.
+	CommitmentPrefix []byte `protobuf:"bytes,17,opt,name=commitment_prefix,json=commitmentPrefix,proto3" json:"commitment_prefix,omitempty"`
+	// index of pallet-timestamp in the runtime of para_id, whose set call is the timestamp extrinsic of the
+	// parachain headers, a zero index is the default index 3.
+	TimestampPalletIndex uint32 `protobuf:"varint,18,opt,name=timestamp_pallet_index,json=timestampPalletIndex,proto3" json:"timestamp_pallet_index,omitempty"`
 }
 
 func (m *ClientState) Reset()         { *m = ClientState{} }
@@ -102,6 +215,71 @@ func (m *ClientState) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ClientState proto.InternalMessageInfo
 
+// Parachain tracked by the client alongside the para_id of the client state
+type AdditionalParachain struct {
+	// para id of the parachain
+	ParaId uint32 `protobuf:"varint,1,opt,name=para_id,json=paraId,proto3" json:"para_id,omitempty"`
+	// commitment prefix of the ibc state of the parachain, which can't be the prefix of another tracked parachain
+	CommitmentPrefix []byte `protobuf:"bytes,2,opt,name=commitment_prefix,json=commitmentPrefix,proto3" json:"commitment_prefix,omitempty"`
+	// index of pallet-timestamp in the runtime of the parachain, a zero index is the default index 3.
+	TimestampPalletIndex uint32 `protobuf:"varint,3,opt,name=timestamp_pallet_index,json=timestampPalletIndex,proto3" json:"timestamp_pallet_index,omitempty"`
+}
+
+func (m *AdditionalParachain) Reset()         { *m = AdditionalParachain{} }
+func (m *AdditionalParachain) String() string { return proto.CompactTextString(m) }
+func (*AdditionalParachain) ProtoMessage()    {}
+func (*AdditionalParachain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_71b13aa2f4351d30, []int{1}
+}
+func (m *AdditionalParachain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdditionalParachain.Unmarshal(m, b)
+}
+func (m *AdditionalParachain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdditionalParachain.Marshal(b, m, deterministic)
+}
+func (m *AdditionalParachain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdditionalParachain.Merge(m, src)
+}
+func (m *AdditionalParachain) XXX_Size() int {
+	return xxx_messageInfo_AdditionalParachain.Size(m)
+}
+func (m *AdditionalParachain) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdditionalParachain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdditionalParachain proto.InternalMessageInfo
+
+// Supermajority is the fraction of an authority set that must be exceeded by the number of
+// signatures for a commitment to be final.
+type Supermajority struct {
+	Numerator   uint32 `protobuf:"varint,1,opt,name=numerator,proto3" json:"numerator,omitempty"`
+	Denominator uint32 `protobuf:"varint,2,opt,name=denominator,proto3" json:"denominator,omitempty"`
+}
+
+func (m *Supermajority) Reset()         { *m = Supermajority{} }
+func (m *Supermajority) String() string { return proto.CompactTextString(m) }
+func (*Supermajority) ProtoMessage()    {}
+func (*Supermajority) Descriptor() ([]byte, []int) {
+	return fileDescriptor_71b13aa2f4351d30, []int{2}
+}
+func (m *Supermajority) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Supermajority.Unmarshal(m, b)
+}
+func (m *Supermajority) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Supermajority.Marshal(b, m, deterministic)
+}
+func (m *Supermajority) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Supermajority.Merge(m, src)
+}
+func (m *Supermajority) XXX_Size() int {
+	return xxx_messageInfo_Supermajority.Size(m)
+}
+func (m *Supermajority) XXX_DiscardUnknown() {
+	xxx_messageInfo_Supermajority.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Supermajority proto.InternalMessageInfo
+
 // Actual payload items
 type PayloadItem struct {
 	// 2-byte payload id
@@ -114,7 +292,7 @@ func (m *PayloadItem) Reset()         { *m = PayloadItem{} }
 func (m *PayloadItem) String() string { return proto.CompactTextString(m) }
 func (*PayloadItem) ProtoMessage()    {}
 func (*PayloadItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{1}
+	return fileDescriptor_71b13aa2f4351d30, []int{3}
 }
 func (m *PayloadItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayloadItem.Unmarshal(m, b)
@@ -148,7 +326,7 @@ func (m *Commitment) Reset()         { *m = Commitment{} }
 func (m *Commitment) String() string { return proto.CompactTextString(m) }
 func (*Commitment) ProtoMessage()    {}
 func (*Commitment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{2}
+	return fileDescriptor_71b13aa2f4351d30, []int{4}
 }
 func (m *Commitment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Commitment.Unmarshal(m, b)
@@ -174,13 +352,15 @@ type CommitmentSignature struct {
 	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
 	// authority leaf index in the merkle tree.
 	AuthorityIndex uint32 `protobuf:"varint,2,opt,name=authority_index,json=authorityIndex,proto3" json:"authority_index,omitempty"`
+	// public key of the authority, for signature schemes whose signer can't be recovered from the signature.
+	PublicKey []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
 }
 
 func (m *CommitmentSignature) Reset()         { *m = CommitmentSignature{} }
 func (m *CommitmentSignature) String() string { return proto.CompactTextString(m) }
 func (*CommitmentSignature) ProtoMessage()    {}
 func (*CommitmentSignature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{3}
+	return fileDescriptor_71b13aa2f4351d30, []int{5}
 }
 func (m *CommitmentSignature) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommitmentSignature.Unmarshal(m, b)
@@ -206,13 +386,15 @@ type SignedCommitment struct {
 	Commitment *Commitment `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
 	// gotten from rpc subscription
 	Signatures []*CommitmentSignature `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	// signature aggregated from the signatures of the authorities, for aggregate signature schemes.
+	AggregateSignature []byte `protobuf:"bytes,3,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
 }
 
 func (m *SignedCommitment) Reset()         { *m = SignedCommitment{} }
 func (m *SignedCommitment) String() string { return proto.CompactTextString(m) }
 func (*SignedCommitment) ProtoMessage()    {}
 func (*SignedCommitment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{4}
+	return fileDescriptor_71b13aa2f4351d30, []int{6}
 }
 func (m *SignedCommitment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommitment.Unmarshal(m, b)
@@ -250,7 +432,7 @@ func (m *ClientStateUpdateProof) Reset()         { *m = ClientStateUpdateProof{}
 func (m *ClientStateUpdateProof) String() string { return proto.CompactTextString(m) }
 func (*ClientStateUpdateProof) ProtoMessage()    {}
 func (*ClientStateUpdateProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{5}
+	return fileDescriptor_71b13aa2f4351d30, []int{7}
 }
 func (m *ClientStateUpdateProof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ClientStateUpdateProof.Unmarshal(m, b)
@@ -283,7 +465,7 @@ func (m *ConsensusState) Reset()         { *m = ConsensusState{} }
 func (m *ConsensusState) String() string { return proto.CompactTextString(m) }
 func (*ConsensusState) ProtoMessage()    {}
 func (*ConsensusState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{6}
+	return fileDescriptor_71b13aa2f4351d30, []int{8}
 }
 func (m *ConsensusState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusState.Unmarshal(m, b)
@@ -308,13 +490,15 @@ var xxx_messageInfo_ConsensusState proto.InternalMessageInfo
 type Misbehaviour struct {
 	Header1 *Header `protobuf:"bytes,2,opt,name=header_1,json=header1,proto3" json:"header_1,omitempty" yaml:"header_1"`
 	Header2 *Header `protobuf:"bytes,3,opt,name=header_2,json=header2,proto3" json:"header_2,omitempty" yaml:"header_2"`
+	// kind of misbehaviour proven by the headers, which decides the parts of the headers it is verified from
+	Type MisbehaviourType `protobuf:"varint,4,opt,name=type,proto3,enum=beefy.v1.MisbehaviourType" json:"type,omitempty"`
 }
 
 func (m *Misbehaviour) Reset()         { *m = Misbehaviour{} }
 func (m *Misbehaviour) String() string { return proto.CompactTextString(m) }
 func (*Misbehaviour) ProtoMessage()    {}
 func (*Misbehaviour) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{7}
+	return fileDescriptor_71b13aa2f4351d30, []int{9}
 }
 func (m *Misbehaviour) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Misbehaviour.Unmarshal(m, b)
@@ -346,7 +530,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{8}
+	return fileDescriptor_71b13aa2f4351d30, []int{10}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -380,7 +564,7 @@ func (m *ConsensusStateUpdateProof) Reset()         { *m = ConsensusStateUpdateP
 func (m *ConsensusStateUpdateProof) String() string { return proto.CompactTextString(m) }
 func (*ConsensusStateUpdateProof) ProtoMessage()    {}
 func (*ConsensusStateUpdateProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{9}
+	return fileDescriptor_71b13aa2f4351d30, []int{11}
 }
 func (m *ConsensusStateUpdateProof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusStateUpdateProof.Unmarshal(m, b)
@@ -437,13 +621,15 @@ type ParachainHeader struct {
 	ExtrinsicProof [][]byte `protobuf:"bytes,6,rep,name=extrinsic_proof,json=extrinsicProof,proto3" json:"extrinsic_proof,omitempty"`
 	// the actual timestamp extrinsic
 	TimestampExtrinsic []byte `protobuf:"bytes,7,opt,name=timestamp_extrinsic,json=timestampExtrinsic,proto3" json:"timestamp_extrinsic,omitempty"`
+	// para id of the parachain, which must be tracked by the client
+	ParaId uint32 `protobuf:"varint,8,opt,name=para_id,json=paraId,proto3" json:"para_id,omitempty"`
 }
 
 func (m *ParachainHeader) Reset()         { *m = ParachainHeader{} }
 func (m *ParachainHeader) String() string { return proto.CompactTextString(m) }
 func (*ParachainHeader) ProtoMessage()    {}
 func (*ParachainHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{10}
+	return fileDescriptor_71b13aa2f4351d30, []int{12}
 }
 func (m *ParachainHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ParachainHeader.Unmarshal(m, b)
@@ -479,7 +665,7 @@ func (m *BeefyMmrLeafPartial) Reset()         { *m = BeefyMmrLeafPartial{} }
 func (m *BeefyMmrLeafPartial) String() string { return proto.CompactTextString(m) }
 func (*BeefyMmrLeafPartial) ProtoMessage()    {}
 func (*BeefyMmrLeafPartial) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{11}
+	return fileDescriptor_71b13aa2f4351d30, []int{13}
 }
 func (m *BeefyMmrLeafPartial) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BeefyMmrLeafPartial.Unmarshal(m, b)
@@ -513,7 +699,7 @@ func (m *BeefyAuthoritySet) Reset()         { *m = BeefyAuthoritySet{} }
 func (m *BeefyAuthoritySet) String() string { return proto.CompactTextString(m) }
 func (*BeefyAuthoritySet) ProtoMessage()    {}
 func (*BeefyAuthoritySet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{12}
+	return fileDescriptor_71b13aa2f4351d30, []int{14}
 }
 func (m *BeefyAuthoritySet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BeefyAuthoritySet.Unmarshal(m, b)
@@ -551,7 +737,7 @@ func (m *BeefyMmrLeaf) Reset()         { *m = BeefyMmrLeaf{} }
 func (m *BeefyMmrLeaf) String() string { return proto.CompactTextString(m) }
 func (*BeefyMmrLeaf) ProtoMessage()    {}
 func (*BeefyMmrLeaf) Descriptor() ([]byte, []int) {
-	return fileDescriptor_71b13aa2f4351d30, []int{13}
+	return fileDescriptor_71b13aa2f4351d30, []int{15}
 }
 func (m *BeefyMmrLeaf) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BeefyMmrLeaf.Unmarshal(m, b)
@@ -574,8 +760,18 @@ var xxx_messageInfo_BeefyMmrLeaf proto.InternalMessageInfo
 func init() {
 	proto.RegisterEnum("beefy.v1.RelayChain", RelayChain_name, RelayChain_value)
 	golang_proto.RegisterEnum("beefy.v1.RelayChain", RelayChain_name, RelayChain_value)
+	proto.RegisterEnum("beefy.v1.RootSource", RootSource_name, RootSource_value)
+	golang_proto.RegisterEnum("beefy.v1.RootSource", RootSource_name, RootSource_value)
+	proto.RegisterEnum("beefy.v1.SignatureType", SignatureType_name, SignatureType_value)
+	golang_proto.RegisterEnum("beefy.v1.SignatureType", SignatureType_name, SignatureType_value)
+	proto.RegisterEnum("beefy.v1.MisbehaviourType", MisbehaviourType_name, MisbehaviourType_value)
+	golang_proto.RegisterEnum("beefy.v1.MisbehaviourType", MisbehaviourType_name, MisbehaviourType_value)
 	proto.RegisterType((*ClientState)(nil), "beefy.v1.ClientState")
 	golang_proto.RegisterType((*ClientState)(nil), "beefy.v1.ClientState")
+	proto.RegisterType((*AdditionalParachain)(nil), "beefy.v1.AdditionalParachain")
+	golang_proto.RegisterType((*AdditionalParachain)(nil), "beefy.v1.AdditionalParachain")
+	proto.RegisterType((*Supermajority)(nil), "beefy.v1.Supermajority")
+	golang_proto.RegisterType((*Supermajority)(nil), "beefy.v1.Supermajority")
 	proto.RegisterType((*PayloadItem)(nil), "beefy.v1.PayloadItem")
 	golang_proto.RegisterType((*PayloadItem)(nil), "beefy.v1.PayloadItem")
 	proto.RegisterType((*Commitment)(nil), "beefy.v1.Commitment")
@@ -608,89 +804,117 @@ func init() { proto.RegisterFile("v1/beefy.proto", fileDescriptor_71b13aa2f4351d
 func init() { golang_proto.RegisterFile("v1/beefy.proto", fileDescriptor_71b13aa2f4351d30) }
 
 var fileDescriptor_71b13aa2f4351d30 = []byte{
-	// 1342 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xcd, 0x8e, 0x13, 0xc7,
-	0x16, 0x76, 0xdb, 0x66, 0x66, 0x7c, 0xec, 0xb1, 0x7b, 0x6a, 0x86, 0xb9, 0x66, 0xb8, 0xd8, 0xbe,
-	0x03, 0xd2, 0x35, 0xdc, 0x8b, 0x1d, 0x1b, 0x12, 0x11, 0xa4, 0x2c, 0xc6, 0x26, 0x80, 0x35, 0xc0,
-	0x8c, 0x6a, 0x60, 0xc3, 0xa6, 0x55, 0xb6, 0x6b, 0xec, 0x56, 0xba, 0xbb, 0xac, 0xee, 0xf2, 0x08,
-	0xf3, 0x04, 0x51, 0xa4, 0x44, 0xbc, 0x40, 0x24, 0x76, 0xd9, 0xe5, 0x01, 0xb2, 0xca, 0x2a, 0x62,
-	0xc9, 0x32, 0x62, 0xe1, 0x44, 0x33, 0x0f, 0x10, 0x29, 0xca, 0x03, 0x44, 0xf5, 0xd3, 0x3f, 0x63,
-	0x8c, 0xd8, 0x67, 0x57, 0x7d, 0xce, 0x57, 0xf5, 0x9d, 0xfa, 0xfa, 0xfc, 0x14, 0x14, 0x4f, 0x5a,
-	0xcd, 0x3e, 0xa5, 0xc7, 0xb3, 0xc6, 0xc4, 0x67, 0x9c, 0xa1, 0x35, 0xf5, 0x71, 0xd2, 0xda, 0xa9,
-	0x8e, 0x18, 0x1b, 0x39, 0xb4, 0x29, 0xed, 0xfd, 0xe9, 0x71, 0x93, 0xdb, 0x2e, 0x0d, 0x38, 0x71,
-	0x27, 0x0a, 0xba, 0xb3, 0x35, 0x62, 0x23, 0x26, 0x97, 0x4d, 0xb1, 0x52, 0xd6, 0xdd, 0x5f, 0x32,
-	0x90, 0xef, 0x3a, 0x36, 0xf5, 0xf8, 0x11, 0x27, 0x9c, 0xa2, 0x5d, 0x58, 0x77, 0x5d, 0xdf, 0xf2,
-	0x19, 0xe3, 0xd6, 0x98, 0x04, 0xe3, 0xb2, 0x51, 0x33, 0xea, 0x05, 0x9c, 0x77, 0x5d, 0x1f, 0x33,
-	0xc6, 0x1f, 0x92, 0x60, 0x8c, 0x1a, 0xb0, 0xe9, 0x10, 0x4e, 0x03, 0x6e, 0x49, 0x76, 0x6b, 0x4c,
-	0xed, 0xd1, 0x98, 0x97, 0xd3, 0x35, 0xa3, 0xbe, 0x8e, 0x37, 0x94, 0xab, 0x23, 0x3c, 0x0f, 0xa5,
-	0x03, 0x5d, 0x85, 0xf5, 0x63, 0x9f, 0xbd, 0xa4, 0x5e, 0x88, 0xcc, 0xd4, 0x8c, 0x7a, 0x16, 0x17,
-	0x94, 0x51, 0x83, 0x3e, 0x85, 0xbc, 0x4f, 0x1d, 0x32, 0xb3, 0x06, 0x63, 0x62, 0x7b, 0xe5, 0x6c,
-	0xcd, 0xa8, 0x17, 0xdb, 0x5b, 0x8d, 0xf0, 0x7e, 0x0d, 0x2c, 0x9c, 0x5d, 0xe1, 0xc3, 0xe0, 0x47,
-	0x6b, 0xf4, 0x2f, 0x58, 0x9d, 0x10, 0x9f, 0x58, 0xf6, 0xb0, 0x7c, 0x41, 0xf2, 0xaf, 0x88, 0xcf,
-	0xde, 0x10, 0xfd, 0x1f, 0x90, 0x0e, 0x52, 0xfa, 0x35, 0xf3, 0x8a, 0xc4, 0x98, 0xca, 0x73, 0x48,
-	0x7c, 0xa2, 0xd9, 0x6f, 0xc3, 0xb6, 0xba, 0x0b, 0x19, 0x70, 0xfb, 0x84, 0x70, 0x9b, 0x79, 0x56,
-	0xdf, 0x61, 0x83, 0xaf, 0xca, 0xab, 0x72, 0xc7, 0x96, 0xf4, 0xee, 0x45, 0xce, 0x8e, 0xf0, 0xa1,
-	0xcf, 0x21, 0x47, 0xa6, 0x7c, 0xcc, 0x7c, 0x9b, 0xcf, 0xca, 0x6b, 0x35, 0xa3, 0x9e, 0x6f, 0x5f,
-	0x8e, 0x23, 0x96, 0x12, 0xec, 0x85, 0xfe, 0x23, 0xca, 0x71, 0x8c, 0x46, 0x3d, 0x40, 0x1e, 0x7d,
-	0xc1, 0xad, 0xc8, 0x62, 0x05, 0x94, 0x97, 0x73, 0x1f, 0x3f, 0xc3, 0x14, 0xdb, 0x92, 0x96, 0xbb,
-	0xd9, 0xaf, 0x5f, 0x57, 0x53, 0xbb, 0x14, 0xf2, 0x87, 0x64, 0xe6, 0x30, 0x32, 0xec, 0x71, 0xea,
-	0xa2, 0x9b, 0x00, 0x13, 0xf5, 0x29, 0xa4, 0x91, 0x3f, 0xb1, 0x53, 0x7c, 0x37, 0xaf, 0xc2, 0x91,
-	0xfd, 0x92, 0x0e, 0x3b, 0x33, 0x4e, 0xdb, 0x38, 0xa7, 0x11, 0xbd, 0x21, 0xfa, 0x0f, 0x14, 0x42,
-	0xf8, 0x90, 0x70, 0x22, 0xff, 0x65, 0x01, 0xe7, 0xb5, 0xed, 0x1e, 0xe1, 0x44, 0xd3, 0x7c, 0x67,
-	0x00, 0x74, 0x99, 0xeb, 0xda, 0xdc, 0xa5, 0x1e, 0x47, 0x4d, 0x21, 0xbf, 0xc4, 0x94, 0x8d, 0x5a,
-	0xa6, 0x9e, 0x6f, 0x5f, 0x8c, 0x63, 0x4f, 0x84, 0x83, 0x43, 0x14, 0xaa, 0x42, 0x5e, 0xea, 0x6a,
-	0x79, 0x53, 0x97, 0xfa, 0x3a, 0x67, 0x40, 0x9a, 0x9e, 0x08, 0x0b, 0xaa, 0x83, 0x79, 0x42, 0x1c,
-	0x7b, 0x48, 0x38, 0xf3, 0x85, 0x26, 0x22, 0x7c, 0x95, 0x2f, 0xc5, 0xc8, 0x7e, 0x44, 0x79, 0x6f,
-	0xa8, 0x03, 0xea, 0xc3, 0x66, 0x1c, 0xcf, 0x91, 0x3d, 0xf2, 0x08, 0x9f, 0xfa, 0x14, 0xfd, 0x1b,
-	0x72, 0x41, 0xf8, 0xa1, 0x73, 0x38, 0x36, 0xa0, 0xff, 0x42, 0x29, 0x16, 0xde, 0xf6, 0x86, 0xf4,
-	0x85, 0x8e, 0xa4, 0x18, 0x99, 0x7b, 0xc2, 0xaa, 0x39, 0xbe, 0x35, 0xc0, 0x14, 0x47, 0xd3, 0x61,
-	0xe2, 0xea, 0xb7, 0x01, 0x06, 0xd1, 0x97, 0xa4, 0xc8, 0x27, 0xf3, 0x35, 0x46, 0xe2, 0x04, 0x0e,
-	0x7d, 0x01, 0x10, 0x85, 0x11, 0x94, 0xd3, 0x52, 0xb3, 0x2b, 0xcb, 0x76, 0x45, 0x57, 0xc1, 0x89,
-	0x0d, 0x3a, 0x9e, 0x6f, 0xd2, 0xb0, 0x9d, 0x28, 0xda, 0x67, 0x93, 0x21, 0xe1, 0xf4, 0xd0, 0x67,
-	0xec, 0x18, 0xb5, 0x60, 0x4d, 0xd4, 0xaf, 0x43, 0xc9, 0xb1, 0x8e, 0x69, 0x7b, 0x21, 0x9b, 0x1e,
-	0xbb, 0xfe, 0x23, 0x4a, 0x8e, 0xf1, 0xaa, 0xab, 0x16, 0xe8, 0x1a, 0x14, 0xc3, 0x2d, 0x09, 0x2d,
-	0xb2, 0xb8, 0xa0, 0x01, 0x52, 0x09, 0x74, 0x19, 0x72, 0x02, 0x35, 0x11, 0x2c, 0xe5, 0x4c, 0x2d,
-	0x53, 0x2f, 0x60, 0xc1, 0xa4, 0x58, 0x1f, 0xc0, 0x46, 0x20, 0xf5, 0xb1, 0x12, 0x92, 0x64, 0x25,
-	0xfd, 0x4e, 0x4c, 0xbf, 0x28, 0x21, 0x36, 0x83, 0x45, 0x51, 0xff, 0x07, 0x1b, 0xe1, 0x1f, 0xb0,
-	0x69, 0xa0, 0xd9, 0x2e, 0x48, 0x36, 0x33, 0xe1, 0x90, 0xac, 0x5a, 0x0c, 0x0f, 0x8a, 0x5d, 0xe6,
-	0x05, 0xd4, 0x0b, 0xa6, 0x81, 0xea, 0x61, 0x1d, 0xc8, 0x45, 0xcd, 0x4f, 0x8b, 0xb0, 0xd3, 0x50,
-	0xed, 0xb1, 0x11, 0xb6, 0xc7, 0xc6, 0xd3, 0x10, 0xd1, 0x59, 0x7b, 0x33, 0xaf, 0xa6, 0x5e, 0xfd,
-	0x56, 0x35, 0x70, 0xbc, 0x0d, 0x21, 0xc8, 0x8a, 0x1e, 0xa8, 0x0b, 0x41, 0xae, 0x35, 0xdf, 0x8f,
-	0x06, 0x14, 0x1e, 0xdb, 0x41, 0x9f, 0x8e, 0xc9, 0x89, 0xcd, 0xa6, 0x3e, 0xda, 0x87, 0xb5, 0x31,
-	0x25, 0x43, 0xea, 0x5b, 0x2d, 0x09, 0xcf, 0xb7, 0xcd, 0xf8, 0xce, 0x0f, 0xa5, 0xa7, 0x53, 0x39,
-	0x9d, 0x57, 0x57, 0xd5, 0xba, 0xf5, 0xe7, 0xbc, 0x5a, 0x9a, 0x11, 0xd7, 0xb9, 0xbb, 0x1b, 0x6e,
-	0xdb, 0xc5, 0xab, 0x6a, 0xd9, 0x4a, 0x1c, 0xd6, 0x96, 0x69, 0xff, 0x91, 0xc3, 0xda, 0xef, 0x1d,
-	0xd6, 0x8e, 0x0e, 0x6b, 0xeb, 0x80, 0x7f, 0x32, 0x60, 0x45, 0xa1, 0x91, 0x05, 0xdb, 0x83, 0x50,
-	0x2b, 0x2b, 0x10, 0x62, 0x59, 0x53, 0x99, 0x3c, 0x5a, 0xa6, 0xab, 0xc9, 0x4c, 0x4c, 0x6a, 0x9a,
-	0x48, 0xb1, 0x4e, 0xf6, 0xcd, 0xbc, 0x6a, 0xe0, 0xad, 0xc1, 0x12, 0x00, 0xea, 0x41, 0x61, 0x20,
-	0x13, 0x53, 0x9d, 0xae, 0xf5, 0xa8, 0x25, 0x8e, 0x5d, 0x9a, 0xb6, 0xfa, 0xcc, 0xfc, 0x20, 0xf6,
-	0xea, 0xe0, 0xbf, 0x37, 0xe0, 0xd2, 0x07, 0x43, 0x41, 0xf7, 0x61, 0x43, 0x74, 0x77, 0x39, 0x32,
-	0x2c, 0x75, 0xeb, 0x40, 0x37, 0xa2, 0x4b, 0xc9, 0x46, 0xa4, 0x21, 0x4a, 0x05, 0x6c, 0x4e, 0xce,
-	0x1b, 0x02, 0x74, 0x05, 0x20, 0x4a, 0x6e, 0x55, 0x95, 0x05, 0x9c, 0x0b, 0xb3, 0x3b, 0x40, 0x97,
-	0x54, 0x51, 0x05, 0xf6, 0x4b, 0xaa, 0x7b, 0x91, 0x28, 0x1e, 0xd1, 0x49, 0x77, 0xff, 0x48, 0x43,
-	0x69, 0xe1, 0x7c, 0x74, 0x1d, 0xcc, 0xc5, 0xa8, 0x74, 0x0b, 0x2a, 0x2d, 0x30, 0xa3, 0x07, 0x60,
-	0x46, 0xb5, 0x37, 0x21, 0x3e, 0xb7, 0x89, 0xa3, 0x35, 0xbb, 0xb2, 0xbc, 0x6c, 0x0f, 0x15, 0x08,
-	0x17, 0xdd, 0x73, 0xdf, 0xa8, 0x0d, 0x17, 0xcf, 0x73, 0x06, 0xe7, 0x4a, 0x75, 0xf3, 0x1c, 0xb1,
-	0xaa, 0x1f, 0xd1, 0x6a, 0x15, 0x32, 0x51, 0xfa, 0x59, 0xd5, 0x06, 0xa5, 0x3d, 0x2e, 0xfe, 0x1b,
-	0xb0, 0xa1, 0x90, 0x9c, 0x71, 0xe2, 0x58, 0x03, 0x36, 0xf5, 0xb8, 0x9e, 0xb7, 0x25, 0xe9, 0x78,
-	0x2a, 0xec, 0x5d, 0x61, 0x16, 0xbd, 0x95, 0xbe, 0xe0, 0xbe, 0xed, 0x05, 0xf6, 0x40, 0xc7, 0xb0,
-	0x22, 0x63, 0x28, 0x46, 0x66, 0x45, 0xdf, 0x84, 0xcd, 0xa8, 0xde, 0xac, 0xc8, 0x27, 0x07, 0x6e,
-	0x01, 0xa3, 0xc8, 0xf5, 0x65, 0xe8, 0xd1, 0x19, 0xf1, 0x97, 0x01, 0x9b, 0x4b, 0x14, 0x41, 0xd7,
-	0x60, 0xf5, 0x84, 0xfa, 0x81, 0xcd, 0x3c, 0x29, 0xf6, 0x7a, 0x07, 0x44, 0x5d, 0xbf, 0x9b, 0x57,
-	0xd3, 0xcf, 0xee, 0xe0, 0xd0, 0x25, 0xde, 0x22, 0x13, 0xe2, 0x8b, 0x04, 0xf5, 0xa6, 0x6e, 0x3f,
-	0x9a, 0x40, 0x05, 0x65, 0x7c, 0x22, 0x6d, 0xe8, 0x13, 0xc8, 0x6b, 0x90, 0x7c, 0x02, 0x65, 0xe4,
-	0xf4, 0x2c, 0xbd, 0x9b, 0x57, 0xf3, 0xd1, 0xf4, 0xbc, 0xd5, 0xc6, 0xa0, 0x30, 0xf2, 0x49, 0xf4,
-	0x1c, 0xca, 0xea, 0xfd, 0xb0, 0x64, 0xa8, 0x67, 0x3f, 0x3a, 0xd4, 0x65, 0xfa, 0xa7, 0xf0, 0x45,
-	0x89, 0x78, 0xb2, 0x7c, 0xbe, 0x07, 0xb0, 0xf1, 0xde, 0x3e, 0x54, 0x84, 0xb4, 0x9e, 0xee, 0x59,
-	0x9c, 0xb6, 0x87, 0xc8, 0x84, 0x8c, 0x43, 0x3d, 0x7d, 0x27, 0xb1, 0x44, 0x9f, 0x41, 0x3c, 0xd2,
-	0xe4, 0xab, 0xee, 0x43, 0xb7, 0x59, 0x8f, 0x60, 0x38, 0xee, 0x75, 0x3f, 0xa4, 0xa1, 0x90, 0xd4,
-	0xfa, 0x1f, 0x2b, 0x32, 0xba, 0x03, 0xa5, 0x85, 0xfa, 0x91, 0xf9, 0xbd, 0x24, 0xa2, 0xe2, 0xf9,
-	0x52, 0x52, 0x4a, 0xdd, 0x68, 0x03, 0xc4, 0x2f, 0x54, 0x54, 0x80, 0xb5, 0xc3, 0x83, 0x47, 0xfb,
-	0x7b, 0xf7, 0x0e, 0x9e, 0x9a, 0x29, 0x04, 0xb0, 0xb2, 0xff, 0xec, 0x68, 0xef, 0xf1, 0x9e, 0x69,
-	0x88, 0x35, 0x3e, 0xe8, 0x1e, 0x74, 0x0f, 0xcc, 0x74, 0x67, 0xff, 0xcd, 0x69, 0x25, 0xf5, 0xf6,
-	0xb4, 0x92, 0xfa, 0xfd, 0xb4, 0x92, 0x7a, 0x75, 0x56, 0x49, 0xbd, 0x3e, 0xab, 0xa4, 0x7e, 0x3e,
-	0xab, 0x18, 0x6f, 0xcf, 0x2a, 0xa9, 0x5f, 0xcf, 0x2a, 0xa9, 0xe7, 0xd7, 0x47, 0x36, 0x1f, 0x4f,
-	0xfb, 0x8d, 0x01, 0x73, 0x9b, 0x5d, 0xe6, 0x4e, 0x58, 0x40, 0xfa, 0x0e, 0xbd, 0x6f, 0x37, 0xed,
-	0x41, 0xd0, 0x6a, 0xdd, 0x94, 0x57, 0x69, 0xf2, 0xd9, 0x84, 0x06, 0xfd, 0x15, 0x39, 0xd9, 0x6e,
-	0xfd, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x9a, 0xf5, 0x79, 0xdb, 0x22, 0x0c, 0x00, 0x00,
+	// 1780 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcb, 0x6f, 0x1b, 0xc7,
+	0x19, 0xe7, 0x4a, 0xb4, 0x24, 0x7e, 0x7c, 0xad, 0x46, 0xb2, 0xb3, 0x76, 0x6a, 0x92, 0x55, 0x02,
+	0x54, 0x76, 0x12, 0x32, 0xa4, 0xd3, 0xc2, 0x0d, 0xd0, 0x02, 0x24, 0x25, 0xdb, 0x84, 0x6c, 0x91,
+	0x1d, 0x4a, 0x45, 0x91, 0xcb, 0x62, 0xc8, 0x1d, 0x91, 0xdb, 0xec, 0x83, 0xd8, 0x1d, 0x0a, 0xa2,
+	0x6f, 0xbd, 0x05, 0x3d, 0x14, 0xb9, 0xb5, 0x97, 0x02, 0xb9, 0xf5, 0xd4, 0x7f, 0xa0, 0x87, 0xa2,
+	0xc7, 0x1c, 0x73, 0x2c, 0x7c, 0x50, 0x0b, 0xeb, 0x3f, 0x28, 0xfa, 0x07, 0x14, 0xf3, 0xd8, 0x07,
+	0x29, 0x06, 0x6e, 0xaf, 0xbd, 0xcd, 0x7e, 0xbf, 0xef, 0xbd, 0xdf, 0x63, 0x06, 0x4a, 0x97, 0xcd,
+	0xc6, 0x88, 0xd2, 0x8b, 0x45, 0x7d, 0x16, 0xf8, 0xcc, 0x47, 0x3b, 0xf2, 0xe3, 0xb2, 0xf9, 0xa0,
+	0x3a, 0xf1, 0xfd, 0x89, 0x43, 0x1b, 0x82, 0x3e, 0x9a, 0x5f, 0x34, 0x98, 0xed, 0xd2, 0x90, 0x11,
+	0x77, 0x26, 0x59, 0x1f, 0x54, 0x56, 0x19, 0xac, 0x79, 0x40, 0x98, 0xed, 0x7b, 0x0a, 0xdf, 0x9f,
+	0xf8, 0x13, 0x5f, 0x1c, 0x1b, 0xfc, 0x24, 0xa9, 0x07, 0x7f, 0xde, 0x81, 0x7c, 0xd7, 0xb1, 0xa9,
+	0xc7, 0x86, 0x8c, 0x30, 0x8a, 0x0e, 0xa0, 0xe8, 0xba, 0x81, 0x19, 0xf8, 0x3e, 0x33, 0xa7, 0x24,
+	0x9c, 0x1a, 0x5a, 0x4d, 0x3b, 0x2c, 0xe0, 0xbc, 0xeb, 0x06, 0xd8, 0xf7, 0xd9, 0x0b, 0x12, 0x4e,
+	0x51, 0x1d, 0xf6, 0x1c, 0xc2, 0x68, 0xc8, 0x4c, 0xe1, 0x9d, 0x39, 0xa5, 0xf6, 0x64, 0xca, 0x8c,
+	0x8d, 0x9a, 0x76, 0x58, 0xc4, 0xbb, 0x12, 0xea, 0x70, 0xe4, 0x85, 0x00, 0xd0, 0x07, 0x50, 0xbc,
+	0x08, 0xfc, 0xd7, 0xd4, 0x8b, 0x38, 0x37, 0x6b, 0xda, 0x61, 0x16, 0x17, 0x24, 0x51, 0x31, 0xfd,
+	0x18, 0xf2, 0x01, 0x75, 0xc8, 0xc2, 0x1c, 0x4f, 0x89, 0xed, 0x19, 0xd9, 0x9a, 0x76, 0x58, 0x6a,
+	0xed, 0xd7, 0xa3, 0xf8, 0xeb, 0x98, 0x83, 0x5d, 0x8e, 0x61, 0x08, 0xe2, 0x33, 0x7a, 0x0f, 0xb6,
+	0x67, 0x24, 0x20, 0xa6, 0x6d, 0x19, 0x77, 0x84, 0xfd, 0x2d, 0xfe, 0xd9, 0xb3, 0xd0, 0xc7, 0x80,
+	0x94, 0x93, 0x02, 0x57, 0x96, 0xb7, 0x04, 0x8f, 0x2e, 0x91, 0x01, 0x09, 0x88, 0xb2, 0xfe, 0x19,
+	0xdc, 0x93, 0xb1, 0x90, 0x31, 0xb3, 0x2f, 0x45, 0xda, 0xcc, 0x91, 0xe3, 0x8f, 0xbf, 0x34, 0xb6,
+	0x85, 0xc4, 0xbe, 0x40, 0xdb, 0x31, 0xd8, 0xe1, 0x18, 0xfa, 0x29, 0xe4, 0xc8, 0x9c, 0x4d, 0xfd,
+	0xc0, 0x66, 0x0b, 0x63, 0xa7, 0xa6, 0x1d, 0xe6, 0x5b, 0xef, 0x27, 0x1e, 0x8b, 0x14, 0xb4, 0x23,
+	0x7c, 0x48, 0x19, 0x4e, 0xb8, 0x51, 0x0f, 0x90, 0x47, 0xaf, 0x98, 0x19, 0x53, 0xcc, 0x90, 0x32,
+	0x23, 0xf7, 0x6e, 0x1d, 0x3a, 0x17, 0x4b, 0x53, 0x90, 0x03, 0xb5, 0xb1, 0xef, 0x85, 0xd4, 0x0b,
+	0xe7, 0xa1, 0x19, 0xf2, 0xbf, 0x68, 0x06, 0x94, 0x51, 0x4f, 0x04, 0x31, 0xa3, 0x81, 0xed, 0x5b,
+	0x06, 0x08, 0xc5, 0xf7, 0xeb, 0xb2, 0x46, 0xea, 0x51, 0x8d, 0xd4, 0x8f, 0x54, 0x8d, 0x74, 0x76,
+	0xbe, 0xbd, 0xae, 0x66, 0xfe, 0xf0, 0x8f, 0xaa, 0x86, 0x1f, 0xc6, 0xca, 0x44, 0x45, 0xe0, 0x48,
+	0xd5, 0x40, 0x68, 0x42, 0x9f, 0xc2, 0xbe, 0x4b, 0xae, 0xcc, 0x15, 0x8b, 0xa1, 0x91, 0x17, 0x79,
+	0x42, 0x2e, 0xb9, 0xea, 0x2e, 0xc9, 0x87, 0xe8, 0x25, 0x94, 0x59, 0x30, 0x0f, 0x99, 0xed, 0x4d,
+	0x22, 0x77, 0x0a, 0xff, 0xbd, 0x3b, 0xa5, 0x48, 0x56, 0xd9, 0xff, 0x15, 0xdc, 0x25, 0x96, 0x65,
+	0x73, 0x2e, 0xe2, 0x88, 0x7f, 0x2b, 0x0a, 0x26, 0x34, 0x8a, 0xb5, 0xcd, 0xc3, 0x7c, 0xeb, 0x61,
+	0x92, 0xbb, 0x76, 0xcc, 0x36, 0x88, 0xb8, 0x3a, 0x59, 0xae, 0x17, 0xef, 0x93, 0xdb, 0x50, 0x28,
+	0x2a, 0x90, 0x97, 0x7d, 0xe8, 0xcf, 0x83, 0x31, 0x35, 0x4a, 0xb7, 0x2a, 0xd0, 0xf7, 0xd9, 0x50,
+	0x60, 0x18, 0x82, 0xf8, 0x8c, 0x7e, 0x0e, 0xa5, 0xd0, 0x9e, 0x78, 0x84, 0xcd, 0x03, 0x6a, 0xb2,
+	0xc5, 0x8c, 0x1a, 0x65, 0x21, 0xf9, 0x5e, 0x22, 0x39, 0x8c, 0xf0, 0xb3, 0xc5, 0x8c, 0xe2, 0x62,
+	0x98, 0xfe, 0x44, 0x5d, 0x28, 0x86, 0xf3, 0x19, 0x0d, 0x5c, 0xf2, 0x6b, 0x59, 0x48, 0xba, 0x48,
+	0x4e, 0x5a, 0x3c, 0x0d, 0xab, 0x10, 0x96, 0x65, 0xd0, 0x47, 0xb0, 0x3b, 0xf6, 0x5d, 0xd7, 0x66,
+	0x2e, 0xf5, 0x98, 0x39, 0x0b, 0xe8, 0x85, 0x7d, 0x65, 0xec, 0x8a, 0xd6, 0xd5, 0x13, 0x60, 0x20,
+	0xe8, 0xbc, 0xd8, 0xe3, 0xe1, 0x61, 0xce, 0x88, 0xe3, 0x50, 0x66, 0xda, 0x9e, 0x45, 0xaf, 0x0c,
+	0x24, 0x8b, 0x3d, 0x46, 0x07, 0x02, 0xec, 0x71, 0xec, 0xf3, 0xec, 0x57, 0xdf, 0x54, 0x33, 0x07,
+	0xbf, 0xd7, 0x60, 0x6f, 0x4d, 0x62, 0xd3, 0x7d, 0xa8, 0x2d, 0xf5, 0xe1, 0x5a, 0xcf, 0x36, 0xfe,
+	0x67, 0xcf, 0x36, 0xdf, 0xe9, 0xd9, 0x39, 0x14, 0x97, 0x12, 0x85, 0x7e, 0x00, 0x39, 0x6f, 0xee,
+	0xd2, 0x80, 0x30, 0x3f, 0x50, 0x4e, 0x25, 0x04, 0x54, 0x83, 0xbc, 0x45, 0x3d, 0xdf, 0xb5, 0x3d,
+	0x81, 0xcb, 0xe1, 0x95, 0x26, 0x29, 0xb5, 0x14, 0xf2, 0x03, 0xb2, 0x70, 0x7c, 0x62, 0xf5, 0x18,
+	0x75, 0xd1, 0x27, 0x00, 0x33, 0xf9, 0x19, 0x85, 0x5a, 0xe8, 0x94, 0xde, 0x5c, 0x57, 0x61, 0x68,
+	0xbf, 0xa6, 0x56, 0x67, 0xc1, 0x68, 0x0b, 0xe7, 0x14, 0x47, 0xcf, 0x42, 0x3f, 0x84, 0x42, 0xc4,
+	0x6e, 0x11, 0x46, 0x54, 0xe0, 0x79, 0x45, 0x3b, 0x22, 0x8c, 0x28, 0x33, 0xbf, 0xd3, 0x00, 0xba,
+	0x71, 0x3a, 0x50, 0x03, 0xb6, 0x15, 0x8f, 0xa1, 0x89, 0xba, 0xbe, 0x9b, 0x94, 0x43, 0xca, 0x1d,
+	0x1c, 0x71, 0xa1, 0x2a, 0xe4, 0xc5, 0xbc, 0x32, 0x45, 0x84, 0x2a, 0x1c, 0x10, 0xa4, 0x53, 0x4e,
+	0x41, 0x87, 0xa0, 0x5f, 0x12, 0xc7, 0xb6, 0x78, 0x68, 0x7c, 0xd6, 0x70, 0xf7, 0xe5, 0x1c, 0x2e,
+	0xc5, 0xf4, 0x21, 0x65, 0x3d, 0x4b, 0x39, 0xf4, 0x1b, 0x0d, 0xf6, 0x12, 0x87, 0xe2, 0x0a, 0xe6,
+	0x59, 0x8d, 0xeb, 0x57, 0x2d, 0x87, 0x84, 0x80, 0x7e, 0x04, 0xe5, 0x64, 0xa2, 0xc9, 0x3f, 0x27,
+	0x5d, 0x29, 0xc5, 0x64, 0xf1, 0xcf, 0xd0, 0x43, 0x80, 0xd9, 0x7c, 0xe4, 0xd8, 0x63, 0xf3, 0x4b,
+	0xba, 0x10, 0x8e, 0x14, 0x70, 0x4e, 0x52, 0x4e, 0xe8, 0x42, 0xf9, 0xf0, 0x57, 0x0d, 0x74, 0x6e,
+	0x99, 0x5a, 0xa9, 0xd4, 0x7c, 0x06, 0x90, 0xd4, 0x8d, 0xf0, 0x20, 0x9f, 0xee, 0xd2, 0x84, 0x13,
+	0xa7, 0xf8, 0xd0, 0xcf, 0x00, 0x62, 0x2f, 0x43, 0x63, 0x63, 0x75, 0x56, 0xac, 0x89, 0x14, 0xa7,
+	0x04, 0x50, 0x03, 0xf6, 0xc8, 0x64, 0x12, 0xd0, 0x09, 0x9f, 0xae, 0x49, 0xfc, 0xd2, 0x6f, 0x14,
+	0x43, 0xb1, 0xb0, 0x0a, 0xe0, 0xb7, 0x1b, 0x70, 0x2f, 0xb5, 0x5d, 0xcf, 0x67, 0x16, 0x61, 0x74,
+	0x10, 0xf8, 0xfe, 0x05, 0x6a, 0xc2, 0x0e, 0x5f, 0xb4, 0x0e, 0x25, 0x17, 0x2a, 0x88, 0x7b, 0x2b,
+	0x63, 0xff, 0x95, 0x1b, 0xbc, 0xa4, 0xe4, 0x02, 0x6f, 0xbb, 0xf2, 0x80, 0x3e, 0x84, 0x52, 0x24,
+	0x92, 0xca, 0x6d, 0x16, 0x17, 0x14, 0x83, 0xcc, 0xec, 0xfb, 0x90, 0xe3, 0x5c, 0x33, 0x6e, 0xc5,
+	0xd8, 0xac, 0x6d, 0x1e, 0x16, 0x30, 0xb7, 0x24, 0xad, 0x3e, 0x87, 0xdd, 0x50, 0x24, 0xd4, 0x4c,
+	0xe5, 0x30, 0x2b, 0xcc, 0x3f, 0x58, 0x9e, 0x57, 0xe9, 0x9c, 0x63, 0x3d, 0x5c, 0xfd, 0x0b, 0x1f,
+	0xc1, 0x6e, 0xf4, 0x47, 0x6d, 0x1a, 0x2a, 0x6b, 0x77, 0x84, 0x35, 0x3d, 0x05, 0x08, 0xab, 0x2a,
+	0x19, 0x1e, 0x94, 0x96, 0x57, 0x03, 0xea, 0x40, 0x2e, 0x6e, 0x68, 0x95, 0x84, 0x07, 0xb7, 0x76,
+	0xc2, 0x59, 0xc4, 0x21, 0x97, 0xc2, 0xd7, 0x7c, 0x29, 0x24, 0x62, 0x08, 0x41, 0x36, 0xf0, 0x7d,
+	0xa6, 0x3a, 0x4b, 0x9c, 0x95, 0xbd, 0x6b, 0x0d, 0x0a, 0xaf, 0xec, 0x70, 0x44, 0xa7, 0xe4, 0xd2,
+	0xf6, 0xe7, 0x01, 0x3a, 0x81, 0x9d, 0x29, 0x25, 0x16, 0x0d, 0xcc, 0xa6, 0x60, 0xcf, 0xb7, 0xf4,
+	0x24, 0xe6, 0x17, 0x02, 0xe9, 0x54, 0xde, 0x5e, 0x57, 0xb7, 0xe5, 0xb9, 0xf9, 0xaf, 0xeb, 0x6a,
+	0x79, 0x41, 0x5c, 0xe7, 0xf3, 0x83, 0x48, 0xec, 0x00, 0x6f, 0xcb, 0x63, 0x33, 0xa5, 0xac, 0x65,
+	0x6c, 0xbe, 0x5b, 0x59, 0xeb, 0x96, 0xb2, 0x56, 0xac, 0xac, 0x85, 0xea, 0x90, 0x15, 0x9b, 0x43,
+	0xde, 0x7a, 0x52, 0x7f, 0x22, 0xed, 0xbf, 0x58, 0x1e, 0x82, 0x4f, 0x05, 0xf8, 0x17, 0x0d, 0xb6,
+	0xa4, 0x76, 0x64, 0xc2, 0xbd, 0xd5, 0x3b, 0xc0, 0x5c, 0x14, 0x9b, 0x4a, 0xeb, 0x07, 0xe9, 0x52,
+	0x4f, 0xff, 0x83, 0x54, 0x49, 0x8a, 0xcd, 0xa2, 0xe1, 0xfd, 0xf1, 0x1a, 0x06, 0xd4, 0x83, 0xc2,
+	0x58, 0x14, 0xb2, 0xd4, 0xae, 0xf2, 0x57, 0x4b, 0xa9, 0x5d, 0x5b, 0xe6, 0x4a, 0x67, 0x7e, 0x9c,
+	0xa0, 0xca, 0xf9, 0x3f, 0x6a, 0x70, 0xff, 0x7b, 0x5d, 0x41, 0xcf, 0x60, 0x37, 0x5e, 0xed, 0xa6,
+	0xcc, 0x52, 0xa8, 0x26, 0xe1, 0xfd, 0xf4, 0x24, 0x54, 0x2c, 0x32, 0x0b, 0x58, 0x9f, 0x2d, 0x13,
+	0x42, 0x3e, 0x66, 0xe2, 0x66, 0x90, 0x6d, 0x5f, 0xc0, 0xb9, 0xa8, 0x1b, 0x42, 0x74, 0x5f, 0x36,
+	0x61, 0x68, 0xbf, 0xa6, 0x6a, 0x18, 0xf2, 0x66, 0xe3, 0xa3, 0xfc, 0xe0, 0xab, 0x4d, 0x28, 0xaf,
+	0xe8, 0x47, 0x8f, 0x40, 0x5f, 0xf5, 0x4a, 0x8d, 0xc0, 0xf2, 0x8a, 0x65, 0xf4, 0x1c, 0xf4, 0xb8,
+	0x57, 0x67, 0x24, 0x60, 0x36, 0x71, 0x54, 0xce, 0x1e, 0xae, 0x6f, 0xf3, 0x81, 0x64, 0xc2, 0x25,
+	0x77, 0xe9, 0x1b, 0xb5, 0xe0, 0xee, 0xb2, 0xcd, 0x70, 0xa9, 0xb5, 0xf7, 0x96, 0x0c, 0xcb, 0x7e,
+	0xe3, 0xb3, 0x5e, 0x72, 0xa6, 0x46, 0x45, 0x56, 0x8e, 0x61, 0x41, 0x4f, 0x86, 0xc5, 0x63, 0xd8,
+	0x95, 0x9c, 0xcc, 0x67, 0xc4, 0x31, 0xc7, 0xfe, 0xdc, 0x63, 0xea, 0x22, 0x5d, 0x16, 0xc0, 0x19,
+	0xa7, 0x77, 0x39, 0x99, 0xcf, 0x76, 0x7a, 0xc5, 0x02, 0xdb, 0x0b, 0xed, 0xb1, 0xf2, 0x61, 0x4b,
+	0xf8, 0x50, 0x8a, 0xc9, 0xd2, 0x7c, 0x03, 0xf6, 0x92, 0x2d, 0x1e, 0x63, 0xe2, 0x26, 0x5d, 0xc0,
+	0x28, 0x86, 0x8e, 0x23, 0x24, 0x7d, 0x79, 0xd8, 0x49, 0x5f, 0x1e, 0x54, 0xa9, 0xfc, 0x5b, 0x83,
+	0xbd, 0x35, 0xa9, 0x42, 0x1f, 0xc2, 0xf6, 0x25, 0x0d, 0x42, 0xdb, 0xf7, 0xe4, 0x7a, 0xef, 0x00,
+	0x1f, 0x10, 0x6f, 0xae, 0xab, 0x1b, 0xe7, 0x4f, 0x71, 0x04, 0xf1, 0xd7, 0xc7, 0x8c, 0x04, 0xbc,
+	0x72, 0xbd, 0xb9, 0x3b, 0x8a, 0x77, 0x63, 0x41, 0x12, 0x4f, 0x05, 0x0d, 0x7d, 0x0a, 0x79, 0xc5,
+	0x24, 0x1e, 0x3d, 0x62, 0xae, 0x77, 0xca, 0x6f, 0xae, 0xab, 0xf9, 0x78, 0xaf, 0x3f, 0x69, 0x61,
+	0x90, 0x3c, 0xe2, 0x11, 0xf4, 0x05, 0x18, 0xf2, 0xc5, 0xb0, 0xe6, 0x1a, 0x9f, 0x7d, 0xe7, 0x35,
+	0x5e, 0xdd, 0xe2, 0xee, 0x0a, 0x8e, 0xd3, 0x95, 0x1b, 0xbd, 0x0a, 0x3b, 0x84, 0xdd, 0x5b, 0x72,
+	0xa8, 0x04, 0x1b, 0xea, 0xde, 0x91, 0xc5, 0x1b, 0xb6, 0x85, 0x74, 0xd8, 0x74, 0xa8, 0xa7, 0x62,
+	0xe2, 0x47, 0xf4, 0x13, 0x48, 0x76, 0xad, 0x78, 0xc7, 0x7d, 0x5f, 0x34, 0xc5, 0x98, 0x0d, 0x27,
+	0x43, 0xf3, 0x4f, 0x1b, 0x50, 0x48, 0xe7, 0xfa, 0xff, 0x36, 0xc9, 0xe8, 0x29, 0x94, 0x57, 0x1a,
+	0xcb, 0xb8, 0xb3, 0xde, 0xa3, 0xd2, 0x72, 0x8f, 0xc9, 0x4c, 0x3d, 0x6e, 0x01, 0x24, 0x6f, 0x52,
+	0x54, 0x80, 0x9d, 0x41, 0xff, 0xe5, 0x49, 0xfb, 0xa8, 0x7f, 0xa6, 0x67, 0x10, 0xc0, 0xd6, 0xc9,
+	0xf9, 0xb0, 0xfd, 0xaa, 0xad, 0x6b, 0xfc, 0x8c, 0xfb, 0xdd, 0x7e, 0xb7, 0xaf, 0x6f, 0x3c, 0xfe,
+	0x18, 0x20, 0x79, 0x45, 0xa0, 0x12, 0xc0, 0xf0, 0xac, 0x7d, 0x76, 0x6c, 0xe2, 0xbe, 0x90, 0x2a,
+	0x01, 0xf4, 0x3a, 0x5d, 0xf3, 0xa8, 0xf7, 0xfc, 0x78, 0x78, 0xa6, 0x6b, 0x8f, 0x1f, 0x41, 0x71,
+	0xe9, 0xe5, 0x80, 0x72, 0x70, 0xe7, 0xb8, 0x7b, 0x34, 0x6c, 0xeb, 0x19, 0x54, 0x84, 0x5c, 0xe7,
+	0xe5, 0xb0, 0xd9, 0x32, 0x9f, 0x3c, 0x6d, 0xea, 0xda, 0xe3, 0xa7, 0xa0, 0xaf, 0xae, 0x0a, 0xa4,
+	0x43, 0xe1, 0xf8, 0x17, 0xe7, 0xbd, 0x5f, 0xf6, 0xbb, 0xed, 0xb3, 0x5e, 0xff, 0x54, 0xcf, 0x20,
+	0x04, 0xa5, 0x41, 0x1b, 0xb7, 0xbb, 0x2f, 0xda, 0xbd, 0x53, 0xf3, 0x59, 0x1f, 0x9f, 0xe8, 0x5a,
+	0xe7, 0xe4, 0xdb, 0xb7, 0x95, 0xcc, 0x77, 0x6f, 0x2b, 0x99, 0x7f, 0xbe, 0xad, 0x64, 0xbe, 0xbe,
+	0xa9, 0x64, 0xbe, 0xb9, 0xa9, 0x64, 0xfe, 0x76, 0x53, 0xd1, 0xbe, 0xbb, 0xa9, 0x64, 0xfe, 0x7e,
+	0x53, 0xc9, 0x7c, 0xf1, 0x68, 0x62, 0xb3, 0xe9, 0x7c, 0x54, 0x1f, 0xfb, 0x6e, 0xa3, 0xeb, 0xbb,
+	0x33, 0x3f, 0x24, 0x23, 0x87, 0x3e, 0xb3, 0x1b, 0xf6, 0x38, 0x6c, 0x36, 0x3f, 0x11, 0xd9, 0x6d,
+	0xf0, 0xb5, 0x14, 0x8e, 0xb6, 0xc4, 0xd6, 0x7e, 0xf2, 0x9f, 0x01, 0x00, 0x7b, 0x3f, 0x88, 0x56,
+	0xc7, 0x10, 0x00, 0x00,
 }
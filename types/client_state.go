@@ -1,11 +1,13 @@
 package types
 
 import (
+	"bytes"
+	"math"
 	"strings"
+	"time"
 
 	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
 
-	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -15,7 +17,6 @@ import (
 	commitmenttypes "github.com/cosmos/ibc-go/v5/modules/core/23-commitment/types"
 	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
 	"github.com/cosmos/ibc-go/v5/modules/core/exported"
-	"github.com/ethereum/go-ethereum/log"
 )
 
 var _ exported.ClientState = (*ClientState)(nil)
@@ -25,9 +26,10 @@ func (cs ClientState) ClientType() string {
 	return Beefy
 }
 
-// GetLatestHeight returns latest block height.
+// GetLatestHeight returns the latest parachain height, the height of the latest consensus state of ParaId.
+// 02-client stores the initial and upgraded consensus states at this height, like every other consensus state.
 func (cs ClientState) GetLatestHeight() exported.Height {
-	return clienttypes.NewHeight(0, uint64(cs.LatestBeefyHeight))
+	return ParachainHeight(cs.ParaId, cs.LatestParaHeight)
 }
 
 // Validate performs basic validation of the client state fields.
@@ -36,6 +38,164 @@ func (cs ClientState) Validate() error {
 		return ErrInvalidHeaderHeight
 	}
 
+	if cs.LatestBeefyHeight < cs.BeefyActivationBlock {
+		return sdkerrors.Wrapf(ErrInvalidHeaderHeight, "latest beefy height %d cannot be lower than the beefy activation block %d",
+			cs.LatestBeefyHeight, cs.BeefyActivationBlock)
+	}
+
+	// a relay chain block includes at most one block of the parachain
+	if cs.LatestParaHeight > cs.LatestBeefyHeight {
+		return sdkerrors.Wrapf(ErrInvalidHeaderHeight, "latest parachain height %d cannot be higher than the latest beefy height %d",
+			cs.LatestParaHeight, cs.LatestBeefyHeight)
+	}
+
+	if len(cs.MmrRootHash) != 32 {
+		return sdkerrors.Wrapf(ErrInvalidRootHash, "mmr root hash must be 32 bytes, got %d", len(cs.MmrRootHash))
+	}
+
+	if cs.ParaId == 0 {
+		return sdkerrors.Wrap(ErrInvalidParaID, "para id cannot be zero")
+	}
+
+	seen := make(map[uint32]bool)
+	for _, paraId := range cs.TrackedParaIds() {
+		if paraId == 0 {
+			return sdkerrors.Wrap(ErrInvalidParaID, "additional para ids cannot be zero")
+		}
+		if seen[paraId] {
+			return sdkerrors.Wrapf(ErrInvalidParaID, "para id %d is tracked more than once", paraId)
+		}
+		seen[paraId] = true
+	}
+
+	for _, parachain := range cs.trackedParachains() {
+		if parachain.TimestampPalletIndex > math.MaxUint8 {
+			return sdkerrors.Wrapf(ErrInvalidTimestampPallet, "timestamp pallet index %d of parachain %d does not fit in a pallet index",
+				parachain.TimestampPalletIndex, parachain.ParaId)
+		}
+	}
+
+	// proofs are bound to a parachain by their commitment prefix, so the prefixes of a client tracking
+	// several parachains must tell them apart
+	if len(cs.AdditionalParachains) > 0 {
+		prefixes := make(map[string]uint32)
+		for _, parachain := range cs.trackedParachains() {
+			if len(parachain.CommitmentPrefix) == 0 {
+				return sdkerrors.Wrapf(commitmenttypes.ErrInvalidPrefix, "parachain %d must have a commitment prefix when several parachains are tracked",
+					parachain.ParaId)
+			}
+			if paraId, ok := prefixes[string(parachain.CommitmentPrefix)]; ok {
+				return sdkerrors.Wrapf(commitmenttypes.ErrInvalidPrefix, "parachains %d and %d have the same commitment prefix %s",
+					paraId, parachain.ParaId, parachain.CommitmentPrefix)
+			}
+			prefixes[string(parachain.CommitmentPrefix)] = parachain.ParaId
+		}
+	}
+
+	if _, ok := RelayChain_name[int32(cs.RelayChain)]; !ok {
+		return sdkerrors.Wrapf(ErrInvalidRelayChain, "unknown relay chain %d", cs.RelayChain)
+	}
+
+	if err := cs.supermajority().Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidSupermajority, err.Error())
+	}
+
+	if _, ok := RootSource_name[int32(cs.RootSource)]; !ok {
+		return sdkerrors.Wrapf(ErrInvalidRootSource, "unknown root source %d", cs.RootSource)
+	}
+
+	if _, err := cs.signatureScheme(); err != nil {
+		return err
+	}
+
+	if err := cs.Authority.Validate(); err != nil {
+		return sdkerrors.Wrap(err, "invalid current authority set")
+	}
+
+	if err := cs.NextAuthoritySet.Validate(); err != nil {
+		return sdkerrors.Wrap(err, "invalid next authority set")
+	}
+
+	if cs.NextAuthoritySet.Id != cs.Authority.Id && cs.NextAuthoritySet.Id != cs.Authority.Id+1 {
+		return sdkerrors.Wrapf(ErrInvalidAuthoritySet, "next authority set id %d must follow the current authority set id %d",
+			cs.NextAuthoritySet.Id, cs.Authority.Id)
+	}
+
+	return nil
+}
+
+// TrackedParaIds returns the para ids of all parachains tracked by the client, starting with ParaId.
+func (cs ClientState) TrackedParaIds() []uint32 {
+	paraIds := []uint32{cs.ParaId}
+	for _, parachain := range cs.AdditionalParachains {
+		paraIds = append(paraIds, parachain.ParaId)
+	}
+	return paraIds
+}
+
+// trackedParachains returns all parachains tracked by the client, starting with ParaId.
+func (cs ClientState) trackedParachains() []AdditionalParachain {
+	parachain := AdditionalParachain{ParaId: cs.ParaId, CommitmentPrefix: cs.CommitmentPrefix, TimestampPalletIndex: cs.TimestampPalletIndex}
+	return append([]AdditionalParachain{parachain}, cs.AdditionalParachains...)
+}
+
+// timestampPalletIndex returns the index of pallet-timestamp in the runtime of the tracked parachain.
+func (cs ClientState) timestampPalletIndex(paraId uint32) uint8 {
+	for _, parachain := range cs.trackedParachains() {
+		if parachain.ParaId == paraId && parachain.TimestampPalletIndex != 0 {
+			return uint8(parachain.TimestampPalletIndex)
+		}
+	}
+
+	return DefaultTimestampPalletIndex
+}
+
+// paraIdOfPrefix returns the para id of the tracked parachain that keeps its ibc state under the commitment
+// prefix. A client that tracks a single parachain without a commitment prefix accepts any prefix for it.
+func (cs ClientState) paraIdOfPrefix(prefix []byte) (uint32, error) {
+	if len(cs.AdditionalParachains) == 0 && len(cs.CommitmentPrefix) == 0 {
+		return cs.ParaId, nil
+	}
+
+	for _, parachain := range cs.trackedParachains() {
+		if bytes.Equal(parachain.CommitmentPrefix, prefix) {
+			return parachain.ParaId, nil
+		}
+	}
+
+	return 0, sdkerrors.Wrapf(commitmenttypes.ErrInvalidPrefix, "no tracked parachain has the commitment prefix %s", prefix)
+}
+
+// IsTrackedParaId returns true if the client tracks the parachain with the given para id.
+func (cs ClientState) IsTrackedParaId(paraId uint32) bool {
+	for _, id := range cs.TrackedParaIds() {
+		if id == paraId {
+			return true
+		}
+	}
+	return false
+}
+
+// ParachainHeight returns the height at which the consensus state of the given parachain block is stored,
+// the para id is used as the revision number so that every parachain has its own range of heights.
+func ParachainHeight(paraId, blockNumber uint32) clienttypes.Height {
+	return clienttypes.NewHeight(uint64(paraId), uint64(blockNumber))
+}
+
+// Validate checks that the authority set is present and has at least one authority.
+func (set *BeefyAuthoritySet) Validate() error {
+	if set == nil {
+		return sdkerrors.Wrap(ErrInvalidAuthoritySet, "authority set cannot be empty")
+	}
+
+	if set.Len == 0 {
+		return sdkerrors.Wrapf(ErrInvalidAuthoritySet, "authority set %d has no authorities", set.Id)
+	}
+
+	if set.AuthorityRoot == nil {
+		return sdkerrors.Wrapf(ErrInvalidAuthoritySet, "authority set %d has no authority root", set.Id)
+	}
+
 	return nil
 }
 
@@ -50,28 +210,83 @@ func (cs ClientState) Initialize(_ sdk.Context, _ codec.BinaryCodec, _ sdk.KVSto
 
 // Status returns the status of the beefy client.
 // The client may be:
-// - Active: if frozen sequence is 0
-// - Frozen: otherwise beefy client is frozen
-func (cs ClientState) Status(_ sdk.Context, _ sdk.KVStore, _ codec.BinaryCodec) exported.Status {
+// - Active: FrozenHeight is zero and the latest consensus state is within the trusting period
+// - Frozen: FrozenHeight is not zero
+// - Expired: the latest consensus state is older than the trusting period
+// - Unknown: the trusting period is set but the latest consensus state can't be found
+func (cs ClientState) Status(ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec) exported.Status {
 	if cs.FrozenHeight > 0 {
 		return exported.Frozen
 	}
 
+	if cs.TrustingPeriod == 0 {
+		return exported.Active
+	}
+
+	consensusState, err := cs.getLatestConsensusState(clientStore, cdc)
+	if err != nil {
+		return exported.Unknown
+	}
+
+	if cs.IsExpired(consensusState.Timestamp, ctx.BlockTime()) {
+		return exported.Expired
+	}
+
 	return exported.Active
 }
 
-// ExportMetadata is a no-op since beefy does not store any metadata in client store
-func (cs ClientState) ExportMetadata(_ sdk.KVStore) []exported.GenesisMetadata {
+// IsExpired returns whether or not the client has passed the trusting period since the
+// latest consensus state was produced.
+func (cs ClientState) IsExpired(latestTimestamp, now time.Time) bool {
+	if cs.TrustingPeriod == 0 {
+		return false
+	}
+
+	expirationTime := latestTimestamp.Add(cs.TrustingPeriod)
+	return !expirationTime.After(now)
+}
+
+// getLatestConsensusState returns the consensus state at the latest parachain height.
+func (cs ClientState) getLatestConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec) (*ConsensusState, error) {
+	return GetConsensusState(clientStore, cdc, cs.GetLatestHeight())
+}
+
+// verifyNotExpired returns an error if the client has expired, or its status is unknown because its latest consensus
+// state is missing. Frozen clients are rejected by produceVerificationArgs.
+// VerifyClientState, VerifyClientConsensusState, VerifyConnectionState and VerifyChannelState don't call it, since
+// they are not given the context of the current block and can't tell whether the trusting period has passed.
+func (cs ClientState) verifyNotExpired(ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec) error {
+	switch status := cs.Status(ctx, clientStore, cdc); status {
+	case exported.Expired:
+		return sdkerrors.Wrapf(ErrClientExpired, "cannot verify proofs against a client whose trusting period of %s has passed", cs.TrustingPeriod)
+	case exported.Unknown:
+		return sdkerrors.Wrapf(clienttypes.ErrClientNotActive, "cannot verify proofs against a client with status %s", status)
+	}
+
 	return nil
 }
 
 // ZeroCustomFields returns a ClientState that is a copy of the current ClientState
-// with all client customizable fields zeroed out
+// with all client customizable fields zeroed out.
+// The chain-specified fields identify the relay chain and parachain, along with its commitment
+// prefix and timestamp pallet index, and the beefy state the upgraded client starts from. The frozen height, retention, trusting period,
+// supermajority and additional parachains are chosen by the relayer and zeroed.
 func (cs ClientState) ZeroCustomFields() exported.ClientState {
 	// copy over all chain-specified fields
 	// and leave custom fields empty
 	return &ClientState{
-		LatestBeefyHeight: 0,
+		RelayChain:           cs.RelayChain,
+		ParaId:               cs.ParaId,
+		BeefyActivationBlock: cs.BeefyActivationBlock,
+		LatestBeefyHeight:    cs.LatestBeefyHeight,
+		MmrRootHash:          cs.MmrRootHash,
+		LatestParaHeight:     cs.LatestParaHeight,
+		Authority:            cs.Authority,
+		NextAuthoritySet:     cs.NextAuthoritySet,
+		RootSource:           cs.RootSource,
+		SignatureType:        cs.SignatureType,
+		CommitmentPrefix:     cs.CommitmentPrefix,
+		TimestampPalletIndex: cs.TimestampPalletIndex,
 	}
 }
 
@@ -117,15 +332,8 @@ func (cs ClientState) VerifyClientState(
 		return sdkerrors.Wrap(err, "keyPath could not be scale encoded")
 	}
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, provingConsensusState.Root, []trie.Pair{{Key: key, Value: csEncoded}})
-	if err != nil {
-		log.Debug("error verifying proof: %v", err.Error())
-	}
-
-	if !isVerified {
-		// return sdkerrors.Wrap(err, "unable to verify client state")
-		log.Debug("unable to verify client state")
+	if err := beefyProof.VerifyMembership(cs.RootSource, provingConsensusState.Root, prefix, key, csEncoded); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify client state")
 	}
 
 	return nil
@@ -174,15 +382,8 @@ func (cs ClientState) VerifyClientConsensusState(
 		return sdkerrors.Wrap(err, "keyPath could not be scale encoded")
 	}
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, provingConsensusState.Root, []trie.Pair{{Key: key, Value: csEncoded}})
-	if err != nil {
-		log.Debug("error verifying proof: %v", err.Error())
-	}
-
-	if !isVerified {
-		// return sdkerrors.Wrap(err, "unable to verify client consensus state")
-		log.Debug("unable to verify client consensus state")
+	if err := beefyProof.VerifyMembership(cs.RootSource, provingConsensusState.Root, prefix, key, csEncoded); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify client consensus state")
 	}
 
 	return nil
@@ -195,8 +396,8 @@ func (cs ClientState) VerifyPacketCommitment(
 	store sdk.KVStore,
 	cdc codec.BinaryCodec,
 	height exported.Height,
-	_ uint64,
-	_ uint64,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
 	prefix exported.Prefix,
 	proof []byte,
 	portID,
@@ -209,6 +410,15 @@ func (cs ClientState) VerifyPacketCommitment(
 		return err
 	}
 
+	if err := cs.verifyNotExpired(ctx, store, cdc); err != nil {
+		return err
+	}
+
+	// check delay period has passed
+	if err := verifyDelayPeriodPassed(ctx, store, height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+
 	commitmentPath := commitmenttypes.NewMerklePath(host.PacketCommitmentPath(portID, channelID, sequence))
 	path, err := commitmenttypes.ApplyPrefix(prefix, commitmentPath)
 	if err != nil {
@@ -221,24 +431,139 @@ func (cs ClientState) VerifyPacketCommitment(
 		return sdkerrors.Wrap(err, "keyPath could not be scale encoded")
 	}
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, consensusState.Root, []trie.Pair{{Key: key, Value: commitmentBytes}})
+	if err := beefyProof.VerifyMembership(cs.RootSource, consensusState.Root, prefix, key, commitmentBytes); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify packet commitment")
+	}
+	return nil
+}
+
+// VerifyMembership verifies a proof of the value stored under the given path, after checking that the
+// delay period has passed since the consensus state at the given height was processed.
+// The path is a marshaled MerklePath whose first key is the commitment prefix.
+func (cs *ClientState) VerifyMembership(
+	ctx sdk.Context,
+	clientStore sdk.KVStore,
+	cdc codec.BinaryCodec,
+	height exported.Height,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
+	proof []byte,
+	path []byte,
+	value []byte,
+) error {
+	prefix, key, err := unmarshalMerklePath(cdc, path)
+	if err != nil {
+		return err
+	}
+
+	beefyProof, consensusState, err := produceVerificationArgs(clientStore, cdc, *cs, height, prefix, proof)
 	if err != nil {
-		log.Debug("error verifying proof: %v", err.Error())
+		return err
 	}
 
-	if !isVerified {
-		// return sdkerrors.Wrap(err, "unable to verify client consensus state")
-		log.Debug("unable to verify client consensus state")
+	if err := cs.verifyNotExpired(ctx, clientStore, cdc); err != nil {
+		return err
 	}
+
+	if err := verifyDelayPeriodPassed(ctx, clientStore, height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+
+	if err := beefyProof.VerifyMembership(cs.RootSource, consensusState.Root, prefix, key, value); err != nil {
+		return sdkerrors.Wrapf(err, "unable to verify membership of %s", key)
+	}
+
 	return nil
 }
 
-type BeefyProof [][]byte
+// VerifyNonMembership verifies a proof of the absence of a value under the given path, after checking
+// that the delay period has passed since the consensus state at the given height was processed.
+// The path is a marshaled MerklePath whose first key is the commitment prefix.
+func (cs *ClientState) VerifyNonMembership(
+	ctx sdk.Context,
+	clientStore sdk.KVStore,
+	cdc codec.BinaryCodec,
+	height exported.Height,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
+	proof []byte,
+	path []byte,
+) error {
+	prefix, key, err := unmarshalMerklePath(cdc, path)
+	if err != nil {
+		return err
+	}
+
+	beefyProof, consensusState, err := produceVerificationArgs(clientStore, cdc, *cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+
+	if err := cs.verifyNotExpired(ctx, clientStore, cdc); err != nil {
+		return err
+	}
+
+	if err := verifyDelayPeriodPassed(ctx, clientStore, height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+
+	if err := beefyProof.VerifyNonMembership(cs.RootSource, consensusState.Root, prefix, key); err != nil {
+		return sdkerrors.Wrapf(err, "unable to verify non-membership of %s", key)
+	}
+
+	return nil
+}
+
+// unmarshalMerklePath decodes a marshaled MerklePath into the commitment prefix, which is its first key,
+// and the raw utf-8 bytes of the full path under which the value is stored in the ibc child trie.
+func unmarshalMerklePath(cdc codec.BinaryCodec, path []byte) (*commitmenttypes.MerklePrefix, []byte, error) {
+	var merklePath commitmenttypes.MerklePath
+	if err := cdc.Unmarshal(path, &merklePath); err != nil {
+		return nil, nil, sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "failed to unmarshal path into MerklePath: %v", err)
+	}
+
+	if len(merklePath.KeyPath) < 2 {
+		return nil, nil, sdkerrors.Wrapf(commitmenttypes.ErrInvalidPrefix, "path %s must contain the commitment prefix", merklePath)
+	}
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte(merklePath.KeyPath[0]))
+	return &prefix, []byte(strings.Join(merklePath.GetKeyPath(), "")), nil
+}
+
+// verifyDelayPeriodPassed checks that the current time and height of this chain have passed the time and
+// height at which the consensus state at the proof height was processed, plus the delay periods.
+func verifyDelayPeriodPassed(ctx sdk.Context, store sdk.KVStore, proofHeight exported.Height, delayTimePeriod, delayBlockPeriod uint64) error {
+	processedTime, err := GetProcessedTime(store, proofHeight)
+	if err != nil {
+		return err
+	}
+	currentTimestamp := uint64(ctx.BlockTime().UnixNano())
+	validTime := processedTime + delayTimePeriod
+	// NOTE: delay time period is inclusive, so if currentTimestamp is validTime, then we return no error
+	if currentTimestamp < validTime {
+		return sdkerrors.Wrapf(ErrDelayPeriodNotPassed, "cannot verify packet until time: %d, current time: %d",
+			validTime, currentTimestamp)
+	}
+
+	processedHeight, err := GetProcessedHeight(store, proofHeight)
+	if err != nil {
+		return err
+	}
+	currentHeight := clienttypes.GetSelfHeight(ctx)
+	validHeight := clienttypes.NewHeight(processedHeight.GetRevisionNumber(), processedHeight.GetRevisionHeight()+delayBlockPeriod)
+	// NOTE: delay block period is inclusive, so if currentHeight is validHeight, then we return no error
+	if currentHeight.LT(validHeight) {
+		return sdkerrors.Wrapf(ErrDelayPeriodNotPassed, "cannot verify packet until height: %s, current height: %s",
+			validHeight, currentHeight)
+	}
+
+	return nil
+}
 
 // produceVerificationArgs performs the basic checks on the arguments that are
 // shared between the verification functions and returns the unmarshalled
-// merkle proof, the consensus state and an error if one occurred.
+// merkle proof, the consensus state and an error if one occurred. The proof
+// height must be a height of the parachain whose commitment prefix is given.
 func produceVerificationArgs(
 	store sdk.KVStore,
 	cdc codec.BinaryCodec,
@@ -247,8 +572,9 @@ func produceVerificationArgs(
 	prefix exported.Prefix,
 	proof []byte,
 ) (beefyProof BeefyProof, consensusState *ConsensusState, err error) {
-	// no height checks because parachain_header height fits into revision_number
-	// so if fetching consensus state fails, we don't have the consensus state for the parachain header.
+	if cs.FrozenHeight > 0 {
+		return BeefyProof{}, nil, sdkerrors.Wrapf(clienttypes.ErrClientFrozen, "cannot verify proofs against a client frozen at height %d", cs.FrozenHeight)
+	}
 
 	if proof == nil {
 		return BeefyProof{}, nil, sdkerrors.Wrap(commitmenttypes.ErrInvalidProof, "proof cannot be empty")
@@ -258,11 +584,22 @@ func produceVerificationArgs(
 		return BeefyProof{}, nil, sdkerrors.Wrap(commitmenttypes.ErrInvalidPrefix, "prefix cannot be empty")
 	}
 
-	_, ok := prefix.(*commitmenttypes.MerklePrefix)
+	merklePrefix, ok := prefix.(*commitmenttypes.MerklePrefix)
 	if !ok {
 		return BeefyProof{}, nil, sdkerrors.Wrapf(commitmenttypes.ErrInvalidPrefix, "invalid prefix type %T, expected *MerklePrefix", prefix)
 	}
 
+	// the ibc state under the prefix is only proven by the consensus states of its own parachain, otherwise
+	// any tracked parachain could prove state for a connection to another one
+	paraId, err := cs.paraIdOfPrefix(merklePrefix.Bytes())
+	if err != nil {
+		return BeefyProof{}, nil, err
+	}
+	if height.GetRevisionNumber() != uint64(paraId) {
+		return BeefyProof{}, nil, sdkerrors.Wrapf(ErrInvalidParaID, "proof height %s is not a height of parachain %d, whose ibc state is under the prefix %s",
+			height, paraId, merklePrefix.Bytes())
+	}
+
 	err = rpcclienttypes.Decode(proof, &beefyProof)
 	if err != nil {
 		return BeefyProof{}, nil, sdkerrors.Wrap(err, "proof couldn't be decoded into BeefyProof struct")
@@ -314,15 +651,8 @@ func (cs ClientState) VerifyConnectionState(
 		return sdkerrors.Wrap(err, "connection state could not be scale encoded")
 	}
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, consensusState.Root, []trie.Pair{{Key: key, Value: connEncoded}})
-	if err != nil {
-		log.Debug("error verifying proof: %v", err.Error())
-	}
-
-	if !isVerified {
-		// return sdkerrors.Wrap(err, "unable to verify client consensus state")
-		log.Debug("unable to verify client consensus state")
+	if err := beefyProof.VerifyMembership(cs.RootSource, consensusState.Root, prefix, key, connEncoded); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify connection state")
 	}
 	return nil
 }
@@ -334,8 +664,8 @@ func (cs ClientState) VerifyPacketAcknowledgement(
 	store sdk.KVStore,
 	cdc codec.BinaryCodec,
 	height exported.Height,
-	_ uint64,
-	_ uint64,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
 	prefix exported.Prefix,
 	proof []byte,
 	portID,
@@ -348,6 +678,15 @@ func (cs ClientState) VerifyPacketAcknowledgement(
 		return err
 	}
 
+	if err := cs.verifyNotExpired(ctx, store, cdc); err != nil {
+		return err
+	}
+
+	// check delay period has passed
+	if err := verifyDelayPeriodPassed(ctx, store, height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+
 	ackPath := commitmenttypes.NewMerklePath(host.PacketAcknowledgementPath(portID, channelID, sequence))
 	path, err := commitmenttypes.ApplyPrefix(prefix, ackPath)
 	if err != nil {
@@ -360,15 +699,8 @@ func (cs ClientState) VerifyPacketAcknowledgement(
 		return sdkerrors.Wrap(err, "keyPath could not be scale encoded")
 	}
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, consensusState.Root, []trie.Pair{{Key: key, Value: channeltypes.CommitAcknowledgement(acknowledgement)}})
-	if err != nil {
-		log.Debug("error verifying proof: %v", err.Error())
-	}
-
-	if !isVerified {
-		// return sdkerrors.Wrap(err, "unable to verify client consensus state")
-		log.Debug("unable to verify client consensus state")
+	if err := beefyProof.VerifyMembership(cs.RootSource, consensusState.Root, prefix, key, channeltypes.CommitAcknowledgement(acknowledgement)); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify packet acknowledgement")
 	}
 
 	return nil
@@ -402,55 +734,66 @@ func (cs ClientState) VerifyChannelState(store sdk.KVStore, cdc codec.BinaryCode
 		return sdkerrors.Wrap(err, "keyPath could not be scale encoded")
 	}
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, consensusState.Root, []trie.Pair{{Key: key, Value: chanEncoded}})
-	if err != nil {
-		log.Debug("error verifying proof: %v", err.Error())
-	}
-
-	if !isVerified {
-		// return sdkerrors.Wrap(err, "unable to verify client consensus state")
-		log.Debug("unable to verify client consensus state")
+	if err := beefyProof.VerifyMembership(cs.RootSource, consensusState.Root, prefix, key, chanEncoded); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify channel state")
 	}
 
 	return nil
 }
 
+// VerifyPacketReceiptAbsence verifies a proof of the absence of an
+// incoming packet receipt at the specified port, specified channel, and
+// specified sequence.
 func (cs ClientState) VerifyPacketReceiptAbsence(
 	ctx sdk.Context,
 	store sdk.KVStore,
 	cdc codec.BinaryCodec,
 	height exported.Height,
-	_ uint64,
-	_ uint64,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
 	prefix exported.Prefix,
 	proof []byte,
 	portID,
 	channelID string,
 	sequence uint64,
 ) error {
-	_, _, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	beefyProof, consensusState, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
 	if err != nil {
 		return err
 	}
 
+	if err := cs.verifyNotExpired(ctx, store, cdc); err != nil {
+		return err
+	}
+
+	// check delay period has passed
+	if err := verifyDelayPeriodPassed(ctx, store, height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+
 	receiptPath := commitmenttypes.NewMerklePath(host.PacketReceiptPath(portID, channelID, sequence))
-	_, err = commitmenttypes.ApplyPrefix(prefix, receiptPath)
+	path, err := commitmenttypes.ApplyPrefix(prefix, receiptPath)
 	if err != nil {
 		return err
 	}
 
-	// TODO: use parity/trie proofOfNonExistence for receipt absence
+	// the key is just the raw utf-8 bytes of the prefix + path
+	key := []byte(strings.Join(path.GetKeyPath(), ""))
+
+	if err := beefyProof.VerifyNonMembership(cs.RootSource, consensusState.Root, prefix, key); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify packet receipt absence")
+	}
+
 	return nil
 }
 
 func (cs ClientState) VerifyNextSequenceRecv(
-	_ sdk.Context,
+	ctx sdk.Context,
 	store sdk.KVStore,
 	cdc codec.BinaryCodec,
 	height exported.Height,
-	_ uint64,
-	_ uint64,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
 	prefix exported.Prefix,
 	proof []byte,
 	portID,
@@ -462,27 +805,28 @@ func (cs ClientState) VerifyNextSequenceRecv(
 		return err
 	}
 
+	if err := cs.verifyNotExpired(ctx, store, cdc); err != nil {
+		return err
+	}
+
+	// check delay period has passed
+	if err := verifyDelayPeriodPassed(ctx, store, height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+
 	nextSequenceRecvPath := commitmenttypes.NewMerklePath(host.NextSequenceRecvPath(portID, channelID))
 	path, err := commitmenttypes.ApplyPrefix(prefix, nextSequenceRecvPath)
 	if err != nil {
 		return err
 	}
 
-	key, err := rpcclienttypes.Encode(path)
-	if err != nil {
-		return sdkerrors.Wrap(err, "next sequence recv path could not be scale encoded")
-	}
+	// the key is just the raw utf-8 bytes of the prefix + path
+	key := []byte(strings.Join(path.GetKeyPath(), ""))
 
 	bz := sdk.Uint64ToBigEndian(nextSequenceRecv)
 
-	// todo: this should be child trie proof verification
-	isVerified, err := trie.VerifyProof(beefyProof, consensusState.Root, []trie.Pair{{Key: key, Value: bz}})
-	if err != nil {
-		return sdkerrors.Wrap(err, "error verifying proof")
-	}
-
-	if !isVerified {
-		log.Debug("unable to verify client consensus state")
+	if err := beefyProof.VerifyMembership(cs.RootSource, consensusState.Root, prefix, key, bz); err != nil {
+		return sdkerrors.Wrap(err, "unable to verify next sequence recv")
 	}
 
 	return nil
@@ -497,13 +841,3 @@ func (cs *ClientState) CheckMisbehaviourAndUpdateState(context sdk.Context, code
 	//TODO implement me
 	panic("implement me")
 }
-
-func (cs *ClientState) CheckSubstituteAndUpdateState(ctx sdk.Context, cdc codec.BinaryCodec, subjectClientStore, substituteClientStore sdk.KVStore, substituteClient exported.ClientState) (exported.ClientState, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (cs *ClientState) VerifyUpgradeAndUpdateState(ctx sdk.Context, cdc codec.BinaryCodec, store sdk.KVStore, newClient exported.ClientState, newConsState exported.ConsensusState, proofUpgradeClient, proofUpgradeConsState []byte) (exported.ClientState, exported.ConsensusState, error) {
-	//TODO implement me
-	panic("implement me")
-}
@@ -0,0 +1,649 @@
+package types_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v5/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
+)
+
+func TestVerifyPacketReceiptAbsence(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	receiptKey := func(sequence uint64) []byte {
+		path, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(host.PacketReceiptPath("transfer", "channel-0", sequence)))
+		require.NoError(t, err)
+		return []byte(strings.Join(path.GetKeyPath(), ""))
+	}
+
+	// receipts for sequence 1 and 3 share a branch, with no child for sequence 2
+	entries := map[string][]byte{
+		string(receiptKey(1)):  {1},
+		string(receiptKey(3)):  {1},
+		string(receiptKey(10)): {1},
+		"unrelated key":        []byte("unrelated value"),
+	}
+	root, proofs := newTestChildTrieProofs(t, prefix.Bytes(), entries, receiptKey(1), receiptKey(10))
+	_, otherProofs := newTestChildTrieProofs(t, prefix.Bytes(), map[string][]byte{string(receiptKey(1)): {1}}, receiptKey(1))
+
+	// a proof that only contains the root node of the child trie
+	childTrie := trie.NewEmptyTrie()
+	for key, value := range entries {
+		childTrie.Put([]byte(key), value)
+	}
+	childRoot, err := childTrie.Hash()
+	require.NoError(t, err)
+
+	var rootOnly beefytypes.BeefyProof
+	require.NoError(t, rpcclienttypes.Decode(proofs[0], &rootOnly))
+	for _, node := range rootOnly.ChildTrieProof {
+		if hash, err := common.Blake2bHash(node); err == nil && hash == childRoot {
+			rootOnly.ChildTrieProof = [][]byte{node}
+			break
+		}
+	}
+	rootOnlyProof, err := rpcclienttypes.Encode(rootOnly)
+	require.NoError(t, err)
+
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, height, root)
+
+	// the ibc child trie doesn't exist at this height
+	emptyHeight := beefytypes.ParachainHeight(PARA_ID, 11)
+	emptyRoot, emptyProofs := newTestChildTrieProofs(t, []byte("other/"), entries, receiptKey(1))
+	setTestConsensusState(t, ctx, cdc, clientStore, emptyHeight, emptyRoot)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, LatestParaHeight: 11, ParaId: PARA_ID}
+
+	testCases := []struct {
+		name     string
+		height   clienttypes.Height
+		proof    []byte
+		sequence uint64
+		expPass  bool
+	}{
+		{"absent receipt in an empty branch slot", height, proofs[0], 2, true},
+		{"absent receipt diverging from a leaf", height, proofs[0], 4, true},
+		{"absent receipt proven with the path of another receipt", height, proofs[1], 11, true},
+		{"absent ibc child trie", emptyHeight, emptyProofs[0], 1, true},
+		{"existing receipt", height, proofs[0], 1, false},
+		{"existing receipt in another proof", height, proofs[1], 10, false},
+		{"path to the receipt is not part of the proof", height, rootOnlyProof, 2, false},
+		{"proof of another trie", height, otherProofs[0], 2, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := clientState.VerifyPacketReceiptAbsence(
+				ctx, clientStore, cdc, tc.height, 0, 0, &prefix, tc.proof, "transfer", "channel-0", tc.sequence,
+			)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, commitmenttypes.ErrInvalidProof)
+			}
+		})
+	}
+}
+
+func TestVerifyPacketCommitment(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	path, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(host.PacketCommitmentPath("transfer", "channel-0", 1)))
+	require.NoError(t, err)
+	key := []byte(strings.Join(path.GetKeyPath(), ""))
+	commitment := []byte("commitment")
+
+	root, proofs := newTestChildTrieProofs(t, prefix.Bytes(), map[string][]byte{
+		string(key):     commitment,
+		"unrelated key": []byte("unrelated value"),
+	}, key)
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, height, root)
+
+	// the same ibc state in the parachain state trie, rather than in the child trie
+	mainRoot, mainProofs := newTestTrieProofs(t, map[string][]byte{string(key): commitment}, key)
+	mainHeight := beefytypes.ParachainHeight(PARA_ID, 11)
+	setTestConsensusState(t, ctx, cdc, clientStore, mainHeight, mainRoot)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, LatestParaHeight: 11, ParaId: PARA_ID}
+
+	testCases := []struct {
+		name             string
+		height           clienttypes.Height
+		delayTimePeriod  uint64
+		delayBlockPeriod uint64
+		proof            []byte
+		prefix           commitmenttypes.MerklePrefix
+		commitment       []byte
+		expPass          bool
+	}{
+		{"valid proof", height, 0, 0, proofs[0], prefix, commitment, true},
+		{"delay periods passed", height, uint64(10 * time.Second), 10, proofs[0], prefix, commitment, true},
+		{"delay time period not passed", height, uint64(11 * time.Second), 0, proofs[0], prefix, commitment, false},
+		{"delay block period not passed", height, 0, 11, proofs[0], prefix, commitment, false},
+		{"wrong commitment", height, 0, 0, proofs[0], prefix, []byte("other commitment"), false},
+		{"wrong child trie", height, 0, 0, proofs[0], commitmenttypes.NewMerklePrefix([]byte("other/")), commitment, false},
+		{"proof against another height", mainHeight, 0, 0, proofs[0], prefix, commitment, false},
+		{"not a child trie proof", mainHeight, 0, 0, mainProofs[0], prefix, commitment, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := clientState.VerifyPacketCommitment(
+				ctx, clientStore, cdc, tc.height, tc.delayTimePeriod, tc.delayBlockPeriod, &tc.prefix, tc.proof, "transfer", "channel-0", 1, tc.commitment,
+			)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyPacketCommitmentOfAnotherParachain(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	path, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(host.PacketCommitmentPath("transfer", "channel-0", 1)))
+	require.NoError(t, err)
+	key := []byte(strings.Join(path.GetKeyPath(), ""))
+	commitment := []byte("commitment")
+
+	// both parachains hold the same ibc state under the prefix of the counterparty parachain PARA_ID
+	root, proofs := newTestChildTrieProofs(t, prefix.Bytes(), map[string][]byte{string(key): commitment}, key)
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, height, root)
+	otherHeight := beefytypes.ParachainHeight(PARA_ID+1, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, otherHeight, root)
+
+	clientState := &beefytypes.ClientState{
+		LatestBeefyHeight:    1,
+		LatestParaHeight:     10,
+		ParaId:               PARA_ID,
+		CommitmentPrefix:     prefix.Bytes(),
+		AdditionalParachains: newTestParachains(PARA_ID + 1),
+	}
+
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &prefix, proofs[0], "transfer", "channel-0", 1, commitment)
+	require.NoError(t, err)
+
+	// a proof against the consensus state of parachain PARA_ID+1 does not prove the state of parachain PARA_ID
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, otherHeight, 0, 0, &prefix, proofs[0], "transfer", "channel-0", 1, commitment)
+	require.ErrorIs(t, err, beefytypes.ErrInvalidParaID)
+
+	unknownPrefix := commitmenttypes.NewMerklePrefix([]byte("other/"))
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &unknownPrefix, proofs[0], "transfer", "channel-0", 1, commitment)
+	require.ErrorIs(t, err, commitmenttypes.ErrInvalidPrefix)
+}
+
+func TestVerifyPacketCommitmentIBCDigest(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	path, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(host.PacketCommitmentPath("transfer", "channel-0", 1)))
+	require.NoError(t, err)
+	key := []byte(strings.Join(path.GetKeyPath(), ""))
+	commitment := []byte("commitment")
+
+	// the root of the ibc child trie is deposited in the digest, so the consensus state root is the child trie root
+	childRoot, childProofs := newTestTrieProofs(t, map[string][]byte{string(key): commitment}, key)
+	var childTrieProof [][]byte
+	require.NoError(t, rpcclienttypes.Decode(childProofs[0], &childTrieProof))
+	proof, err := rpcclienttypes.Encode(beefytypes.BeefyProof{ChildTrieProof: childTrieProof})
+	require.NoError(t, err)
+
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, height, childRoot)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, LatestParaHeight: 10, ParaId: PARA_ID, RootSource: beefytypes.RootSource_IBC_DIGEST}
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment)
+	require.NoError(t, err)
+
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &prefix, proof, "transfer", "channel-0", 1, []byte("other commitment"))
+	require.Error(t, err)
+
+	// the same root is not a parachain state root
+	clientState.RootSource = beefytypes.RootSource_STATE_ROOT
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment)
+	require.Error(t, err)
+}
+
+// setTestConsensusState stores a consensus state with the given root, which was processed 10 seconds and 10 blocks
+// before the given context.
+func setTestConsensusState(t *testing.T, ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, height exported.Height, root []byte) {
+	t.Helper()
+
+	clientStore.Set(host.ConsensusStateKey(height), clienttypes.MustMarshalConsensusState(cdc, &beefytypes.ConsensusState{
+		Timestamp: time.Unix(1643972151, 0),
+		Root:      root,
+	}))
+
+	selfHeight := clienttypes.GetSelfHeight(ctx)
+	beefytypes.SetProcessedTime(clientStore, height, uint64(ctx.BlockTime().Add(-10*time.Second).UnixNano()))
+	beefytypes.SetProcessedHeight(clientStore, height, clienttypes.NewHeight(selfHeight.GetRevisionNumber(), selfHeight.GetRevisionHeight()-10))
+}
+
+// newTestParachains returns additional parachains with the given para ids, whose ibc state is under a commitment
+// prefix of their own.
+func newTestParachains(paraIds ...uint32) []beefytypes.AdditionalParachain {
+	var parachains []beefytypes.AdditionalParachain
+	for _, paraId := range paraIds {
+		parachains = append(parachains, beefytypes.AdditionalParachain{
+			ParaId:           paraId,
+			CommitmentPrefix: []byte(fmt.Sprintf("ibc-%d/", paraId)),
+		})
+	}
+	return parachains
+}
+
+// newTestChildTrieProofs builds a parachain state trie in which the entries are stored in the child trie
+// under the given key. It returns the state root, along with a scale-encoded BeefyProof for each of the given keys.
+func newTestChildTrieProofs(t *testing.T, childKey []byte, entries map[string][]byte, keys ...[]byte) ([]byte, [][]byte) {
+	t.Helper()
+
+	childTrie := trie.NewEmptyTrie()
+	for key, value := range entries {
+		childTrie.Put([]byte(key), value)
+	}
+	childRoot, err := childTrie.Hash()
+	require.NoError(t, err)
+
+	stateTrie := trie.NewEmptyTrie()
+	stateTrie.Put([]byte("unrelated state key"), []byte("unrelated state value"))
+	require.NoError(t, stateTrie.PutChild(childKey, childTrie))
+	stateRoot, err := stateTrie.Hash()
+	require.NoError(t, err)
+
+	db, err := chaindb.NewBadgerDB(&chaindb.Config{InMemory: true, DataDir: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, stateTrie.Store(db))
+	require.NoError(t, childTrie.Store(db))
+
+	childTrieKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), childKey...)
+	childTrieRootProof, err := trie.GenerateProof(stateRoot.ToBytes(), [][]byte{childTrieKey}, db)
+	require.NoError(t, err)
+
+	var proofs [][]byte
+	for _, key := range keys {
+		childTrieProof, err := trie.GenerateProof(childRoot.ToBytes(), [][]byte{key}, db)
+		require.NoError(t, err)
+
+		proof, err := rpcclienttypes.Encode(beefytypes.BeefyProof{
+			ChildTrieRootProof: childTrieRootProof,
+			ChildTrieProof:     childTrieProof,
+		})
+		require.NoError(t, err)
+		proofs = append(proofs, proof)
+	}
+
+	return stateRoot.ToBytes(), proofs
+}
+
+func TestVerifyMembership(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	marshalPath := func(path string) []byte {
+		merklePath, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(path))
+		require.NoError(t, err)
+		bz, err := cdc.Marshal(&merklePath)
+		require.NoError(t, err)
+		return bz
+	}
+	key := func(path string) []byte {
+		merklePath, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(path))
+		require.NoError(t, err)
+		return []byte(strings.Join(merklePath.GetKeyPath(), ""))
+	}
+
+	commitmentPath := host.PacketCommitmentPath("transfer", "channel-0", 1)
+	receiptPath := host.PacketReceiptPath("transfer", "channel-0", 1)
+	commitment := []byte("commitment")
+
+	root, proofs := newTestChildTrieProofs(t, prefix.Bytes(), map[string][]byte{
+		string(key(commitmentPath)): commitment,
+		"unrelated key":             []byte("unrelated value"),
+	}, key(commitmentPath), key(receiptPath))
+
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, height, root)
+
+	unprocessedHeight := beefytypes.ParachainHeight(PARA_ID, 11)
+	clientStore.Set(host.ConsensusStateKey(unprocessedHeight), clientStore.Get(host.ConsensusStateKey(height)))
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, LatestParaHeight: 11, ParaId: PARA_ID}
+
+	testCases := []struct {
+		name             string
+		height           clienttypes.Height
+		delayTimePeriod  uint64
+		delayBlockPeriod uint64
+		proof            []byte
+		path             []byte
+		expErr           error
+	}{
+		{"delay periods passed", height, uint64(10 * time.Second), 10, proofs[0], marshalPath(commitmentPath), nil},
+		{"delay time period not passed", height, uint64(11 * time.Second), 0, proofs[0], marshalPath(commitmentPath), beefytypes.ErrDelayPeriodNotPassed},
+		{"delay block period not passed", height, 0, 11, proofs[0], marshalPath(commitmentPath), beefytypes.ErrDelayPeriodNotPassed},
+		{"processed time not found", unprocessedHeight, 0, 0, proofs[0], marshalPath(commitmentPath), beefytypes.ErrProcessedTimeNotFound},
+		{"consensus state not found", beefytypes.ParachainHeight(PARA_ID, 12), 0, 0, proofs[0], marshalPath(commitmentPath), clienttypes.ErrConsensusStateNotFound},
+		{"path is not a merkle path", height, 0, 0, proofs[0], []byte("path"), commitmenttypes.ErrInvalidProof},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := clientState.VerifyMembership(
+				ctx, clientStore, cdc, tc.height, tc.delayTimePeriod, tc.delayBlockPeriod, tc.proof, tc.path, commitment,
+			)
+			if tc.expErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expErr)
+			}
+		})
+	}
+
+	err := clientState.VerifyMembership(ctx, clientStore, cdc, height, 0, 0, proofs[0], marshalPath(commitmentPath), []byte("other commitment"))
+	require.ErrorIs(t, err, commitmenttypes.ErrInvalidProof)
+
+	// the receipt is absent, while the commitment is not
+	err = clientState.VerifyNonMembership(ctx, clientStore, cdc, height, 0, 10, proofs[1], marshalPath(receiptPath))
+	require.NoError(t, err)
+
+	err = clientState.VerifyNonMembership(ctx, clientStore, cdc, height, 0, 11, proofs[1], marshalPath(receiptPath))
+	require.ErrorIs(t, err, beefytypes.ErrDelayPeriodNotPassed)
+
+	err = clientState.VerifyNonMembership(ctx, clientStore, cdc, height, 0, 0, proofs[0], marshalPath(commitmentPath))
+	require.ErrorIs(t, err, commitmenttypes.ErrInvalidProof)
+}
+
+func TestStatus(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	// the initial consensus state is stored at the latest parachain height of the client when it was created
+	initialHeight := beefytypes.ParachainHeight(PARA_ID, 5)
+	clientStore.Set(host.ConsensusStateKey(initialHeight), clienttypes.MustMarshalConsensusState(cdc, &beefytypes.ConsensusState{
+		Timestamp: ctx.BlockTime().Add(-2 * time.Hour),
+	}))
+	latestHeight := beefytypes.ParachainHeight(PARA_ID, 10)
+	clientStore.Set(host.ConsensusStateKey(latestHeight), clienttypes.MustMarshalConsensusState(cdc, &beefytypes.ConsensusState{
+		Timestamp: ctx.BlockTime().Add(-time.Hour),
+	}))
+
+	testCases := []struct {
+		name        string
+		clientState beefytypes.ClientState
+		expStatus   exported.Status
+	}{
+		{"no trusting period", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 5}, exported.Active},
+		{"frozen", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 5, FrozenHeight: 1, TrustingPeriod: 3 * time.Hour}, exported.Frozen},
+		{"within trusting period", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 5, LatestParaHeight: 10, TrustingPeriod: 90 * time.Minute}, exported.Active},
+		{"trusting period passed", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 5, LatestParaHeight: 10, TrustingPeriod: time.Hour}, exported.Expired},
+		{"initial consensus state within trusting period", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 5, LatestParaHeight: 5, TrustingPeriod: 3 * time.Hour}, exported.Active},
+		{"initial consensus state past trusting period", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 5, LatestParaHeight: 5, TrustingPeriod: 90 * time.Minute}, exported.Expired},
+		{"latest consensus state not found", beefytypes.ClientState{ParaId: PARA_ID, LatestBeefyHeight: 6, LatestParaHeight: 11, TrustingPeriod: time.Hour}, exported.Unknown},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expStatus, tc.clientState.Status(ctx, clientStore, cdc))
+		})
+	}
+}
+
+func TestVerifyExpiredClient(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	path, err := commitmenttypes.ApplyPrefix(&prefix, commitmenttypes.NewMerklePath(host.PacketCommitmentPath("transfer", "channel-0", 1)))
+	require.NoError(t, err)
+	key := []byte(strings.Join(path.GetKeyPath(), ""))
+	commitment := []byte("commitment")
+
+	root, proofs := newTestChildTrieProofs(t, prefix.Bytes(), map[string][]byte{string(key): commitment}, key)
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	setTestConsensusState(t, ctx, cdc, clientStore, height, root)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, LatestParaHeight: 10, ParaId: PARA_ID}
+	clientState.TrustingPeriod = ctx.BlockTime().Sub(time.Unix(1643972151, 0)) + time.Second
+
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &prefix, proofs[0], "transfer", "channel-0", 1, commitment)
+	require.NoError(t, err)
+	_, err = clientState.GetTimestampAtHeight(ctx, clientStore, cdc, height)
+	require.NoError(t, err)
+
+	// a second later the trusting period has passed
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Second))
+	require.Equal(t, exported.Expired, clientState.Status(ctx, clientStore, cdc))
+
+	err = clientState.VerifyPacketCommitment(ctx, clientStore, cdc, height, 0, 0, &prefix, proofs[0], "transfer", "channel-0", 1, commitment)
+	require.ErrorIs(t, err, beefytypes.ErrClientExpired)
+
+	bz, err := cdc.Marshal(&path)
+	require.NoError(t, err)
+	err = clientState.VerifyMembership(ctx, clientStore, cdc, height, 0, 0, proofs[0], bz, commitment)
+	require.ErrorIs(t, err, beefytypes.ErrClientExpired)
+
+	_, err = clientState.GetTimestampAtHeight(ctx, clientStore, cdc, height)
+	require.ErrorIs(t, err, beefytypes.ErrClientExpired)
+
+	// without its latest consensus state the status of the client is unknown rather than expired
+	unknown := *clientState
+	unknown.LatestBeefyHeight, unknown.LatestParaHeight = 2, 11
+	require.Equal(t, exported.Unknown, unknown.Status(ctx, clientStore, cdc))
+
+	err = unknown.VerifyMembership(ctx, clientStore, cdc, height, 0, 0, proofs[0], bz, commitment)
+	require.ErrorIs(t, err, clienttypes.ErrClientNotActive)
+	require.NotErrorIs(t, err, beefytypes.ErrClientExpired)
+}
+
+func TestClientStateValidate(t *testing.T) {
+	authorityRoot := bytes32(crypto.Keccak256([]byte("authority root")))
+	newClientState := func(malleate func(cs *beefytypes.ClientState)) *beefytypes.ClientState {
+		cs := &beefytypes.ClientState{
+			MmrRootHash:          crypto.Keccak256([]byte("mmr root")),
+			LatestBeefyHeight:    100,
+			BeefyActivationBlock: 10,
+			RelayChain:           beefytypes.RelayChain_KUSAMA,
+			ParaId:               PARA_ID,
+			LatestParaHeight:     50,
+			Authority:            &beefytypes.BeefyAuthoritySet{Id: 1, Len: 5, AuthorityRoot: &authorityRoot},
+			NextAuthoritySet:     &beefytypes.BeefyAuthoritySet{Id: 2, Len: 5, AuthorityRoot: &authorityRoot},
+		}
+		malleate(cs)
+		return cs
+	}
+
+	testCases := []struct {
+		name        string
+		clientState *beefytypes.ClientState
+		expErr      error
+	}{
+		{"valid client state", newClientState(func(cs *beefytypes.ClientState) {}), nil},
+		{"next authority set equals the current one", newClientState(func(cs *beefytypes.ClientState) {
+			cs.NextAuthoritySet.Id = 1
+		}), nil},
+		{"zero beefy height", newClientState(func(cs *beefytypes.ClientState) {
+			cs.LatestBeefyHeight, cs.LatestParaHeight, cs.BeefyActivationBlock = 0, 0, 0
+		}), beefytypes.ErrInvalidHeaderHeight},
+		{"beefy height before activation", newClientState(func(cs *beefytypes.ClientState) {
+			cs.BeefyActivationBlock = 101
+		}), beefytypes.ErrInvalidHeaderHeight},
+		{"parachain height above beefy height", newClientState(func(cs *beefytypes.ClientState) {
+			cs.LatestParaHeight = 101
+		}), beefytypes.ErrInvalidHeaderHeight},
+		{"short mmr root hash", newClientState(func(cs *beefytypes.ClientState) {
+			cs.MmrRootHash = cs.MmrRootHash[:31]
+		}), beefytypes.ErrInvalidRootHash},
+		{"zero para id", newClientState(func(cs *beefytypes.ClientState) {
+			cs.ParaId = 0
+		}), beefytypes.ErrInvalidParaID},
+		{"commitment prefix of a single parachain", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+		}), nil},
+		{"additional parachains", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.AdditionalParachains = newTestParachains(PARA_ID+1, PARA_ID+2)
+		}), nil},
+		{"zero additional para id", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.AdditionalParachains = newTestParachains(0)
+		}), beefytypes.ErrInvalidParaID},
+		{"duplicate para id", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.AdditionalParachains = newTestParachains(PARA_ID+1, PARA_ID)
+		}), beefytypes.ErrInvalidParaID},
+		{"additional parachains without the commitment prefix of the client", newClientState(func(cs *beefytypes.ClientState) {
+			cs.AdditionalParachains = newTestParachains(PARA_ID + 1)
+		}), commitmenttypes.ErrInvalidPrefix},
+		{"additional parachain without a commitment prefix", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.AdditionalParachains = []beefytypes.AdditionalParachain{{ParaId: PARA_ID + 1}}
+		}), commitmenttypes.ErrInvalidPrefix},
+		{"parachains sharing a commitment prefix", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.AdditionalParachains = []beefytypes.AdditionalParachain{{ParaId: PARA_ID + 1, CommitmentPrefix: []byte("ibc/")}}
+		}), commitmenttypes.ErrInvalidPrefix},
+		{"timestamp pallet indexes", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.TimestampPalletIndex = 10
+			cs.AdditionalParachains = newTestParachains(PARA_ID + 1)
+			cs.AdditionalParachains[0].TimestampPalletIndex = 255
+		}), nil},
+		{"timestamp pallet index above 255", newClientState(func(cs *beefytypes.ClientState) {
+			cs.TimestampPalletIndex = 256
+		}), beefytypes.ErrInvalidTimestampPallet},
+		{"timestamp pallet index of an additional parachain above 255", newClientState(func(cs *beefytypes.ClientState) {
+			cs.CommitmentPrefix = []byte("ibc/")
+			cs.AdditionalParachains = newTestParachains(PARA_ID + 1)
+			cs.AdditionalParachains[0].TimestampPalletIndex = 256
+		}), beefytypes.ErrInvalidTimestampPallet},
+		{"unknown relay chain", newClientState(func(cs *beefytypes.ClientState) {
+			cs.RelayChain = 3
+		}), beefytypes.ErrInvalidRelayChain},
+		{"ibc digest root source", newClientState(func(cs *beefytypes.ClientState) {
+			cs.RootSource = beefytypes.RootSource_IBC_DIGEST
+		}), nil},
+		{"unknown root source", newClientState(func(cs *beefytypes.ClientState) {
+			cs.RootSource = 2
+		}), beefytypes.ErrInvalidRootSource},
+		{"bls signature scheme", newClientState(func(cs *beefytypes.ClientState) {
+			cs.SignatureType = beefytypes.SignatureType_BLS12_381
+		}), nil},
+		{"unknown signature scheme", newClientState(func(cs *beefytypes.ClientState) {
+			cs.SignatureType = 2
+		}), beefytypes.ErrInvalidSignatureScheme},
+		{"missing authority set", newClientState(func(cs *beefytypes.ClientState) {
+			cs.Authority = nil
+		}), beefytypes.ErrInvalidAuthoritySet},
+		{"missing next authority set", newClientState(func(cs *beefytypes.ClientState) {
+			cs.NextAuthoritySet = nil
+		}), beefytypes.ErrInvalidAuthoritySet},
+		{"empty authority set", newClientState(func(cs *beefytypes.ClientState) {
+			cs.Authority.Len = 0
+		}), beefytypes.ErrInvalidAuthoritySet},
+		{"authority set without root", newClientState(func(cs *beefytypes.ClientState) {
+			cs.NextAuthoritySet.AuthorityRoot = nil
+		}), beefytypes.ErrInvalidAuthoritySet},
+		{"next authority set skips an id", newClientState(func(cs *beefytypes.ClientState) {
+			cs.NextAuthoritySet.Id = 3
+		}), beefytypes.ErrInvalidAuthoritySet},
+		{"next authority set precedes the current one", newClientState(func(cs *beefytypes.ClientState) {
+			cs.NextAuthoritySet.Id = 0
+		}), beefytypes.ErrInvalidAuthoritySet},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.clientState.Validate()
+			if tc.expErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestVerifyClientMessageMissingAuthoritySet(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	header := &beefytypes.Header{
+		ClientState: &beefytypes.ClientStateUpdateProof{
+			SignedCommitment: &beefytypes.SignedCommitment{Commitment: &beefytypes.Commitment{}},
+		},
+	}
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, ParaId: PARA_ID}
+	err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+	require.ErrorIs(t, err, beefytypes.ErrInvalidAuthoritySet)
+
+	err = clientState.VerifyClientMessage(ctx, cdc, clientStore, &beefytypes.Header{})
+	require.ErrorIs(t, err, clienttypes.ErrInvalidHeader)
+}
+
+func TestZeroCustomFields(t *testing.T) {
+	authorityRoot := bytes32(crypto.Keccak256([]byte("authority root")))
+	clientState := &beefytypes.ClientState{
+		MmrRootHash:                   crypto.Keccak256([]byte("mmr root")),
+		LatestBeefyHeight:             100,
+		FrozenHeight:                  1,
+		RelayChain:                    beefytypes.RelayChain_KUSAMA,
+		ParaId:                        PARA_ID,
+		LatestParaHeight:              50,
+		BeefyActivationBlock:          10,
+		Authority:                     &beefytypes.BeefyAuthoritySet{Id: 1, Len: 5, AuthorityRoot: &authorityRoot},
+		NextAuthoritySet:              &beefytypes.BeefyAuthoritySet{Id: 2, Len: 5, AuthorityRoot: &authorityRoot},
+		ConsensusStateRetentionPeriod: time.Hour,
+		MaxConsensusStates:            100,
+		TrustingPeriod:                time.Hour,
+		AdditionalParachains:          newTestParachains(PARA_ID + 1),
+		CommitmentPrefix:              []byte("ibc/"),
+		RootSource:                    beefytypes.RootSource_IBC_DIGEST,
+		SignatureType:                 beefytypes.SignatureType_BLS12_381,
+		Supermajority:                 beefytypes.Supermajority{Numerator: 3, Denominator: 4},
+	}
+
+	zeroed, ok := clientState.ZeroCustomFields().(*beefytypes.ClientState)
+	require.True(t, ok)
+	require.NoError(t, zeroed.Validate())
+
+	// only the relayer chosen fields differ
+	expected := *clientState
+	expected.FrozenHeight = 0
+	expected.ConsensusStateRetentionPeriod = 0
+	expected.MaxConsensusStates = 0
+	expected.TrustingPeriod = 0
+	expected.AdditionalParachains = nil
+	expected.Supermajority = beefytypes.Supermajority{}
+	require.Equal(t, &expected, zeroed)
+}
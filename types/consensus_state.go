@@ -10,6 +10,9 @@ import (
 
 var _ exported.ConsensusState = (*ConsensusState)(nil)
 
+// SentinelRoot is used as a stand-in root value for the consensus state set at the upgrade height
+const SentinelRoot = "sentinel_root"
+
 // ClientType returns Beefy
 func (ConsensusState) ClientType() string {
 	return Beefy
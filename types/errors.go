@@ -24,4 +24,16 @@ var (
 	ErrFailedEncodeMMRLeaf        = sdkerrors.Register(SubModuleName, 11, "failed to encode MMR leaf")
 	ErrFailedVerifyMMRLeaf        = sdkerrors.Register(SubModuleName, 12, "failed to verify MMR leaf")
 	ErrInvalivParachainHeadsProof = sdkerrors.Register(SubModuleName, 13, "invalid parachain heads proof")
+	ErrClientExpired              = sdkerrors.Register(SubModuleName, 14, "client is expired")
+	ErrInvalidAuthoritySet        = sdkerrors.Register(SubModuleName, 15, "invalid authority set")
+	ErrInvalidParaID              = sdkerrors.Register(SubModuleName, 16, "invalid para id")
+	ErrInvalidRelayChain          = sdkerrors.Register(SubModuleName, 17, "invalid relay chain")
+	ErrInvalidParachainHeader     = sdkerrors.Register(SubModuleName, 18, "invalid parachain header")
+	ErrInvalidTimestampExtrinsic  = sdkerrors.Register(SubModuleName, 19, "invalid timestamp extrinsic")
+	ErrInvalidExtrinsicProof      = sdkerrors.Register(SubModuleName, 20, "invalid extrinsic proof")
+	ErrInvalidAuthorityIndex      = sdkerrors.Register(SubModuleName, 21, "invalid authority index")
+	ErrInvalidSignatureScheme     = sdkerrors.Register(SubModuleName, 22, "invalid signature scheme")
+	ErrInvalidRootSource          = sdkerrors.Register(SubModuleName, 23, "invalid root source")
+	ErrInvalidSupermajority       = sdkerrors.Register(SubModuleName, 24, "invalid supermajority")
+	ErrInvalidTimestampPallet     = sdkerrors.Register(SubModuleName, 25, "invalid timestamp pallet")
 )
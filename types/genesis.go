@@ -0,0 +1,79 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+)
+
+// ExportMetadata exports the processed time, processed height and iteration key of every consensus state
+// in the client store, so that delay periods and ordered iteration survive a chain export and restart.
+func (cs ClientState) ExportMetadata(clientStore sdk.KVStore) []exported.GenesisMetadata {
+	gm := make([]exported.GenesisMetadata, 0)
+	IterateConsensusMetadata(clientStore, func(key, val []byte) bool {
+		gm = append(gm, clienttypes.NewGenesisMetadata(key, val))
+		return false
+	})
+	if len(gm) == 0 {
+		return nil
+	}
+	return gm
+}
+
+// ImportMetadata validates the metadata exported by ExportMetadata and writes it to the client store.
+// Nothing is written unless every entry is a valid processed time, processed height or iteration key.
+func ImportMetadata(clientStore sdk.KVStore, metadata []exported.GenesisMetadata) error {
+	for _, md := range metadata {
+		if err := validateMetadata(md.GetKey(), md.GetValue()); err != nil {
+			return err
+		}
+	}
+
+	for _, md := range metadata {
+		clientStore.Set(md.GetKey(), md.GetValue())
+	}
+
+	return nil
+}
+
+// validateMetadata checks that the key is consensus state metadata, and that the value decodes into the
+// type stored under that key.
+func validateMetadata(key, value []byte) error {
+	if bytes.HasPrefix(key, []byte(KeyIterateConsensusStatePrefix)) {
+		if len(key) != len(KeyIterateConsensusStatePrefix)+16 {
+			return sdkerrors.Wrapf(clienttypes.ErrInvalidClientMetadata, "invalid iteration key %x", key)
+		}
+		if height := GetHeightFromIterationKey(key); !bytes.Equal(value, host.ConsensusStateKey(height)) {
+			return sdkerrors.Wrapf(clienttypes.ErrInvalidClientMetadata, "iteration key for height %s must reference its consensus state", height)
+		}
+		return nil
+	}
+
+	if !isProcessedMetadataKey(key) {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidClientMetadata, "unknown metadata key %s", key)
+	}
+
+	// processed metadata keys have the format: "consensusStates/<height>/<processedTime|processedHeight>"
+	keySplit := strings.Split(string(key), "/")
+	if _, err := clienttypes.ParseHeight(keySplit[1]); err != nil {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidClientMetadata, "invalid height in metadata key %s: %v", key, err)
+	}
+
+	if strings.HasSuffix(string(key), string(KeyProcessedTime)) {
+		if len(value) != 8 {
+			return sdkerrors.Wrapf(clienttypes.ErrInvalidClientMetadata, "processed time under %s must be 8 bytes, got %d", key, len(value))
+		}
+		return nil
+	}
+
+	if _, err := clienttypes.ParseHeight(string(value)); err != nil {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidClientMetadata, "invalid processed height under %s: %v", key, err)
+	}
+
+	return nil
+}
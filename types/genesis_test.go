@@ -0,0 +1,91 @@
+package types_test
+
+import (
+	"testing"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
+)
+
+func TestExportImportMetadata(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, ParaId: PARA_ID}
+	require.Nil(t, clientState.ExportMetadata(clientStore))
+
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	heights := clientState.UpdateState(ctx, cdc, clientStore, &beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+			ParachainHeaders: []*beefytypes.ParachainHeader{
+				newTestParachainHeader(t, 10, stateRoot, nil),
+				newTestParachainHeader(t, 11, stateRoot, nil),
+			},
+		},
+	})
+	require.Len(t, heights, 2)
+
+	metadata := clientState.ExportMetadata(clientStore)
+	// processed time, processed height and iteration key for each consensus state
+	require.Len(t, metadata, 6)
+	for _, md := range metadata {
+		require.NotEqual(t, host.ClientStateKey(), md.GetKey(), "client state must not be exported as metadata")
+	}
+
+	importedStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	require.NoError(t, beefytypes.ImportMetadata(importedStore, metadata))
+
+	for _, height := range heights {
+		processedTime, err := beefytypes.GetProcessedTime(importedStore, height)
+		require.NoError(t, err)
+		require.Equal(t, uint64(ctx.BlockTime().UnixNano()), processedTime)
+
+		processedHeight, err := beefytypes.GetProcessedHeight(importedStore, height)
+		require.NoError(t, err)
+		require.Equal(t, clienttypes.GetSelfHeight(ctx), processedHeight)
+	}
+
+	var iterated []exported.Height
+	beefytypes.IterateConsensusStateAscending(importedStore, func(height exported.Height) bool {
+		iterated = append(iterated, height)
+		return false
+	})
+	require.Equal(t, heights, iterated)
+	require.Equal(t, metadata, clientState.ExportMetadata(importedStore))
+}
+
+func TestImportMetadataInvalid(t *testing.T) {
+	height := clienttypes.NewHeight(0, 10)
+	valid := clienttypes.NewGenesisMetadata(beefytypes.ProcessedHeightKey(height), []byte("1-100"))
+
+	testCases := []struct {
+		name     string
+		metadata clienttypes.GenesisMetadata
+	}{
+		{"unknown key", clienttypes.NewGenesisMetadata([]byte("unknown"), []byte{1})},
+		{"consensus state", clienttypes.NewGenesisMetadata(host.ConsensusStateKey(height), []byte{1})},
+		{"invalid processed time", clienttypes.NewGenesisMetadata(beefytypes.ProcessedTimeKey(height), []byte{1})},
+		{"invalid processed height", clienttypes.NewGenesisMetadata(beefytypes.ProcessedHeightKey(height), []byte("height"))},
+		{"iteration key of another height", clienttypes.NewGenesisMetadata(beefytypes.IterationKey(height), host.ConsensusStateKey(clienttypes.NewHeight(0, 11)))},
+		{"truncated iteration key", clienttypes.NewGenesisMetadata([]byte(beefytypes.KeyIterateConsensusStatePrefix), host.ConsensusStateKey(height))},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			clientStore := dbadapter.Store{DB: dbm.NewMemDB()}
+
+			err := beefytypes.ImportMetadata(clientStore, []exported.GenesisMetadata{valid, tc.metadata})
+			require.ErrorIs(t, err, clienttypes.ErrInvalidClientMetadata)
+			require.Nil(t, clientStore.Get(valid.GetKey()), "nothing is imported when any entry is invalid")
+		})
+	}
+}
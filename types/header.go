@@ -2,22 +2,33 @@ package types
 
 import (
 	"bytes"
-	"encoding/binary"
-	"fmt"
-	"log"
+	"math"
+	"sync"
 	"time"
 
-	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ComposableFi/go-substrate-rpc-client/v4/scale"
 	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	ics02 "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
 	"github.com/cosmos/ibc-go/v5/modules/core/exported"
 )
 
 var _ exported.Header = &Header{}
 
-const revisionNumber = 0
+// IBCConsensusEngineID is the engine id of the header digest in which pallet-ibc deposits the ibc commitment root
+var IBCConsensusEngineID = []byte("/IBC")
+
+const (
+	// DefaultTimestampPalletIndex is the index of pallet-timestamp in the runtime of a parachain whose client
+	// state doesn't set one
+	DefaultTimestampPalletIndex = 3
+	// TimestampSetCallIndex is the index of the timestamp.set call in pallet-timestamp
+	TimestampSetCallIndex = 0
+)
+
+// MmrRootPayloadID is the id of the commitment payload that holds the mmr root hash
+var MmrRootPayloadID = []byte("mh")
 
 type Head []byte
 
@@ -42,35 +53,144 @@ func DecodeParachainHeader(hb []byte) (rpcclienttypes.Header, error) {
 	return h, nil
 }
 
-// DecodeExtrinsicTimestamp decodes a scale encoded timestamp to a time.Time type
-func DecodeExtrinsicTimestamp(encodedExtrinsic []byte) (time.Time, error) {
+// DecodeExtrinsicTimestamp decodes a scale encoded timestamp.set extrinsic to a time.Time type, along with the
+// index of the pallet that is called. The index of pallet-timestamp differs between parachain runtimes, so it is
+// left to the client state to check. It returns an error if the extrinsic is not a call to the set call of a
+// pallet, or the timestamp is not a positive unix time in milliseconds that fits in nanoseconds.
+func DecodeExtrinsicTimestamp(encodedExtrinsic []byte) (time.Time, uint8, error) {
 	var extrinsic rpcclienttypes.Extrinsic
 	decodeErr := rpcclienttypes.Decode(encodedExtrinsic, &extrinsic)
 	if decodeErr != nil {
-		return time.Time{}, decodeErr
+		return time.Time{}, 0, sdkerrors.Wrap(ErrInvalidTimestampExtrinsic, decodeErr.Error())
+	}
+
+	callIndex := extrinsic.Method.CallIndex
+	if callIndex.MethodIndex != TimestampSetCallIndex {
+		return time.Time{}, 0, sdkerrors.Wrapf(ErrInvalidTimestampExtrinsic, "expected a call to timestamp.set (%d), got (%d, %d)",
+			TimestampSetCallIndex, callIndex.SectionIndex, callIndex.MethodIndex)
 	}
 
 	unix, unixDecodeErr := scale.NewDecoder(bytes.NewReader(extrinsic.Method.Args[:])).DecodeUintCompact()
 	if unixDecodeErr != nil {
-		return time.Time{}, unixDecodeErr
+		return time.Time{}, 0, sdkerrors.Wrap(ErrInvalidTimestampExtrinsic, unixDecodeErr.Error())
+	}
+	if unix.Sign() <= 0 || !unix.IsInt64() || unix.Int64() > math.MaxInt64/int64(time.Millisecond) {
+		return time.Time{}, 0, sdkerrors.Wrapf(ErrInvalidTimestampExtrinsic, "timestamp %s is out of range", unix)
 	}
 	t := time.UnixMilli(unix.Int64())
 
-	return t, nil
+	return t, callIndex.SectionIndex, nil
 }
 
-// ConsensusState returns the updated consensus state associated with the header
-func (h Header) ConsensusState() *ConsensusState {
-	parachainHeader, err := DecodeParachainHeader(h.ConsensusStateUpdate.ParachainHeaders[0].ParachainHeader)
-	if err != nil {
-		log.Fatal(err)
+// DecodedParachainHeader is a parachain header of the update along with its decoded substrate header and the
+// timestamp proven by its extrinsic proof, so that the functions that need them are handed the decoded header
+// rather than decoding it again.
+type DecodedParachainHeader struct {
+	*ParachainHeader
+	// decoded substrate header of the parachain block
+	Header rpcclienttypes.Header
+	// timestamp set by the timestamp extrinsic of the parachain block
+	Timestamp time.Time
+	// index of the pallet called by the timestamp extrinsic, which must be pallet-timestamp of the parachain
+	TimestampPalletIndex uint8
+}
+
+// decodedHeadersCache holds the decoded parachain headers of the last decoded header. 02-client hands the same
+// header to ValidateBasic, VerifyClientMessage, CheckForMisbehaviour and UpdateState in turn, which would otherwise
+// each decode the parachain headers and prove their timestamps. The cache can't be a field of the Header, since the
+// reflection based proto codec rejects struct fields that are not in the proto definition.
+var decodedHeadersCache struct {
+	sync.Mutex
+	header           *Header
+	parachainHeaders []*ParachainHeader
+	decodedHeaders   []DecodedParachainHeader
+}
+
+// DecodeParachainHeaders decodes every parachain header of the update, and proves its timestamp extrinsic
+// against the extrinsics root of the decoded header. It returns an error naming the index of the first
+// parachain header that is missing, can't be decoded or has an invalid extrinsic proof.
+// The decoded headers of the last decoded header are cached, so a header must not be modified in place once
+// it is decoded. Replacing its parachain headers, or the update itself, discards the cache.
+func (h *Header) DecodeParachainHeaders() ([]DecodedParachainHeader, error) {
+	if h.ConsensusStateUpdate == nil {
+		return nil, sdkerrors.Wrap(ics02.ErrInvalidHeader, "header must contain a consensus state update")
+	}
+
+	parachainHeaders := h.ConsensusStateUpdate.ParachainHeaders
+
+	decodedHeadersCache.Lock()
+	defer decodedHeadersCache.Unlock()
+
+	if decodedHeadersCache.header == h && parachainHeadersEqual(decodedHeadersCache.parachainHeaders, parachainHeaders) {
+		return decodedHeadersCache.decodedHeaders, nil
+	}
+
+	decodedHeaders := make([]DecodedParachainHeader, len(parachainHeaders))
+	for i, parachainHeader := range parachainHeaders {
+		if parachainHeader == nil {
+			return nil, sdkerrors.Wrapf(ErrInvalidParachainHeader, "parachain header %d cannot be empty", i)
+		}
+
+		header, err := DecodeParachainHeader(parachainHeader.ParachainHeader)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(ErrInvalidParachainHeader, "failed to decode parachain header %d: %s", i, err)
+		}
+		decodedHeaders[i] = DecodedParachainHeader{ParachainHeader: parachainHeader, Header: header}
+
+		// the timestamp of the consensus state comes from the extrinsics of the parachain block, which
+		// are committed to by the extrinsics root of the decoded header
+		decodedHeaders[i].Timestamp, decodedHeaders[i].TimestampPalletIndex, err = timestampFromExtrinsicProof(decodedHeaders[i])
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "parachain header %d", i)
+		}
+	}
+
+	decodedHeadersCache.header = h
+	decodedHeadersCache.parachainHeaders = append([]*ParachainHeader{}, parachainHeaders...)
+	decodedHeadersCache.decodedHeaders = decodedHeaders
+
+	return decodedHeaders, nil
+}
+
+// parachainHeadersEqual returns true if both slices hold the same parachain headers.
+func parachainHeadersEqual(a, b []*ParachainHeader) bool {
+	if len(a) != len(b) {
+		return false
 	}
 
-	rootHash := parachainHeader.StateRoot[:]
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
 
-	return &ConsensusState{
-		Root: rootHash,
+// ConsensusStates returns the consensus state of every parachain header in the update, along with the height
+// it is stored at. The timestamps are the ones proven by DecodeParachainHeaders, and the roots are taken from
+// the source configured by the client state.
+func (h *Header) ConsensusStates(cs ClientState) ([]exported.Height, []*ConsensusState, error) {
+	if h.ConsensusStateUpdate == nil {
+		return nil, nil, nil
 	}
+
+	decodedHeaders, err := h.DecodeParachainHeaders()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	heights := make([]exported.Height, len(decodedHeaders))
+	consensusStates := make([]*ConsensusState, len(decodedHeaders))
+	for i, parachainHeader := range decodedHeaders {
+		height, consensusState, err := cs.consensusStateFromParachainHeader(parachainHeader)
+		if err != nil {
+			return nil, nil, sdkerrors.Wrapf(err, "failed to derive consensus state of parachain header %d", i)
+		}
+		heights[i], consensusStates[i] = height, consensusState
+	}
+
+	return heights, consensusStates, nil
 }
 
 // ClientType defines that the Header is a Beefy consensus algorithm
@@ -78,43 +198,56 @@ func (h Header) ClientType() string {
 	return Beefy
 }
 
-// GetHeight returns the current height. It returns 0 if the tendermint
-// header is nil.
-// NOTE: the header.Header is checked to be non nil in ValidateBasic.
-func (h Header) GetHeight() exported.Height {
-	parachainHeader, err := DecodeParachainHeader(h.ConsensusStateUpdate.ParachainHeaders[0].ParachainHeader)
+// Height returns the height of the first parachain header in the update, which is the height its consensus
+// state is stored at. It returns an error if the update has no parachain headers, or the first one can't be
+// decoded.
+func (h Header) Height() (exported.Height, error) {
+	if h.ConsensusStateUpdate == nil || len(h.ConsensusStateUpdate.ParachainHeaders) == 0 {
+		return nil, sdkerrors.Wrap(ics02.ErrInvalidHeader, "header must contain at least one parachain header")
+	}
+
+	parachainHeader := h.ConsensusStateUpdate.ParachainHeaders[0]
+	if parachainHeader == nil {
+		return nil, sdkerrors.Wrap(ErrInvalidParachainHeader, "parachain header 0 cannot be empty")
+	}
+
+	header, err := DecodeParachainHeader(parachainHeader.ParachainHeader)
 	if err != nil {
-		log.Fatal(err)
+		return nil, sdkerrors.Wrapf(ErrInvalidParachainHeader, "failed to decode parachain header 0: %s", err)
 	}
-	return ics02.NewHeight(revisionNumber, uint64(parachainHeader.Number))
+
+	return ParachainHeight(parachainHeader.ParaId, uint32(header.Number)), nil
 }
 
-// ValidateBasic calls the SignedHeader ValidateBasic function and checks
-// that validatorsets are not nil.
-// NOTE: TrustedHeight and TrustedValidators may be empty when creating client
-// with MsgCreateClient
-func (h Header) ValidateBasic() error {
-	for _, header := range h.ConsensusStateUpdate.ParachainHeaders {
-		decHeader, err := DecodeParachainHeader(header.ParachainHeader)
-		if err != nil {
-			return err
-		}
+// GetHeight returns the height of the first parachain header in the update. It returns a zero height if
+// the header has no parachain header that can be decoded.
+// NOTE: ValidateBasic checks that the first parachain header can be decoded.
+func (h Header) GetHeight() exported.Height {
+	height, err := h.Height()
+	if err != nil {
+		return ics02.ZeroHeight()
+	}
+	return height
+}
 
-		rootHash := decHeader.ExtrinsicsRoot[:]
-		extrinsicsProof := header.ExtrinsicProof
+// ValidateBasic checks that the header contains at least one parachain header, and that every parachain
+// header has a para id, can be decoded and has an extrinsic proof of its timestamp extrinsic. The proven extrinsic must be
+// a call to timestamp.set with a positive timestamp, and equal to the TimestampExtrinsic of the header. The index of
+// pallet-timestamp is a parameter of the client state, which VerifyClientMessage checks.
+func (h *Header) ValidateBasic() error {
+	if h.ConsensusStateUpdate == nil || len(h.ConsensusStateUpdate.ParachainHeaders) == 0 {
+		return sdkerrors.Wrap(ics02.ErrInvalidHeader, "header must contain at least one parachain header")
+	}
 
-		key := make([]byte, 4)
-		binary.LittleEndian.PutUint32(key, 0)
-		t := trie.NewEmptyTrie()
-		if err := t.LoadFromProof(extrinsicsProof, rootHash); err != nil {
-			return err
-		}
+	decodedHeaders, err := h.DecodeParachainHeaders()
+	if err != nil {
+		return err
+	}
 
-		if ext := t.Get(key); len(ext) == 0 {
-			return fmt.Errorf("invalid key length")
+	for i, header := range decodedHeaders {
+		if header.ParaId == 0 {
+			return sdkerrors.Wrapf(ErrInvalidParaID, "parachain header %d must have a para id", i)
 		}
-
-		// todo: decode extrinsic.
 	}
 
 	return nil
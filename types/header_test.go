@@ -7,10 +7,13 @@ import (
 	"strings"
 	"testing"
 
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ComposableFi/go-substrate-rpc-client/v4/scale"
 	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
 )
 
 func TestDecodeParachainHeader(t *testing.T) {
@@ -54,6 +57,30 @@ func TestDecodeExtrinsicTimestamp(t *testing.T) {
 
 	require.Equal(t, timeUnix, unix.Uint64(), "failed to decode unix timestamp")
 
+	timestamp, palletIndex, err := beefytypes.DecodeExtrinsicTimestamp(timestampBytes)
+	require.NoError(t, err)
+	require.Equal(t, int64(timeUnix), timestamp.UnixMilli())
+	require.Equal(t, uint8(beefytypes.DefaultTimestampPalletIndex), palletIndex)
+
+	// the index of pallet-timestamp is checked by the client state
+	otherPalletBytes, err := hex.DecodeString("280404000bde4660c47e01")
+	require.NoError(t, err)
+	timestamp, palletIndex, err = beefytypes.DecodeExtrinsicTimestamp(otherPalletBytes)
+	require.NoError(t, err)
+	require.Equal(t, int64(timeUnix), timestamp.UnixMilli())
+	require.Equal(t, uint8(4), palletIndex)
+
+	for name, extrinsic := range map[string]string{
+		"other call":     "280403010bde4660c47e01",
+		"zero timestamp": "1004030000",
+		"no timestamp":   "0c040300",
+	} {
+		extrinsicBytes, err := hex.DecodeString(extrinsic)
+		require.NoError(t, err)
+
+		_, _, err = beefytypes.DecodeExtrinsicTimestamp(extrinsicBytes)
+		require.ErrorIs(t, err, beefytypes.ErrInvalidTimestampExtrinsic, name)
+	}
 }
 
 func TestHeader(t *testing.T) {
@@ -103,6 +130,127 @@ func TestHeader(t *testing.T) {
 	//require.Equal(t, hexify(bbuffer), hexify(newbuffer.Bytes()))
 }
 
+func TestHeaderValidateBasic(t *testing.T) {
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	parachainHeader := newTestParachainHeader(t, 10, stateRoot, nil)
+
+	withoutParaId := *parachainHeader
+	withoutParaId.ParaId = 0
+
+	withoutProof := *parachainHeader
+	withoutProof.ExtrinsicProof = nil
+
+	// a proof that doesn't contain the extrinsics root node
+	malformedProof := *parachainHeader
+	malformedProof.ExtrinsicProof = [][]byte{{0x41, 0x00, 0x04, 0x01}}
+
+	// a valid proof of the extrinsics trie of another header, with a different timestamp
+	otherTimestamp, err := hex.DecodeString("280403000bde4660c47e02")
+	require.NoError(t, err)
+	otherHeaderProof := *parachainHeader
+	otherHeaderProof.ExtrinsicProof = newTestParachainHeaderWithExtrinsic(t, 11, stateRoot, nil, otherTimestamp).ExtrinsicProof
+
+	forgedTimestamp := *parachainHeader
+	forgedTimestamp.TimestampExtrinsic = otherTimestamp
+
+	otherCall, err := hex.DecodeString("280403010bde4660c47e01")
+	require.NoError(t, err)
+	zeroTimestamp, err := hex.DecodeString("1004030000")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		parachainHeaders []*beefytypes.ParachainHeader
+		noUpdate         bool
+		expErr           error
+	}{
+		{"valid header", []*beefytypes.ParachainHeader{parachainHeader}, false, nil},
+		{"missing consensus state update", nil, true, clienttypes.ErrInvalidHeader},
+		{"no parachain headers", nil, false, clienttypes.ErrInvalidHeader},
+		{"nil parachain header", []*beefytypes.ParachainHeader{parachainHeader, nil}, false, beefytypes.ErrInvalidParachainHeader},
+		{"undecodable parachain header", []*beefytypes.ParachainHeader{{ParachainHeader: []byte{1, 2, 3}}}, false, beefytypes.ErrInvalidParachainHeader},
+		{"missing para id", []*beefytypes.ParachainHeader{&withoutParaId}, false, beefytypes.ErrInvalidParaID},
+		{"missing extrinsic proof", []*beefytypes.ParachainHeader{&withoutProof}, false, beefytypes.ErrInvalidExtrinsicProof},
+		{"malformed extrinsic proof", []*beefytypes.ParachainHeader{&malformedProof}, false, beefytypes.ErrInvalidExtrinsicProof},
+		{"extrinsic proof not rooted at the extrinsics root", []*beefytypes.ParachainHeader{&otherHeaderProof}, false, beefytypes.ErrInvalidExtrinsicProof},
+		{"timestamp extrinsic differs from the proven one", []*beefytypes.ParachainHeader{&forgedTimestamp}, false, beefytypes.ErrInvalidExtrinsicProof},
+		{"not a timestamp.set call", []*beefytypes.ParachainHeader{
+			newTestParachainHeaderWithExtrinsic(t, 10, stateRoot, nil, otherCall),
+		}, false, beefytypes.ErrInvalidTimestampExtrinsic},
+		{"zero timestamp", []*beefytypes.ParachainHeader{
+			newTestParachainHeaderWithExtrinsic(t, 10, stateRoot, nil, zeroTimestamp),
+		}, false, beefytypes.ErrInvalidTimestampExtrinsic},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			header := beefytypes.Header{}
+			if !tc.noUpdate {
+				header.ConsensusStateUpdate = &beefytypes.ConsensusStateUpdateProof{ParachainHeaders: tc.parachainHeaders}
+			}
+
+			err := header.ValidateBasic()
+			if tc.expErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expErr)
+			}
+
+			// the height accessors never panic, whether or not the header is valid
+			height, err := header.Height()
+			if tc.expErr == nil {
+				require.NoError(t, err)
+				// the height of the consensus state of the header
+				require.Equal(t, beefytypes.ParachainHeight(PARA_ID, 10), height)
+				require.Equal(t, height, header.GetHeight())
+			} else {
+				require.NotPanics(t, func() { header.GetHeight() })
+			}
+		})
+	}
+}
+
+func TestHeaderDecodeParachainHeaders(t *testing.T) {
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	parachainHeader := newTestParachainHeader(t, 10, stateRoot, nil)
+	parachainHeader.ParaId = PARA_ID
+
+	header := &beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+			ParachainHeaders: []*beefytypes.ParachainHeader{parachainHeader, newTestParachainHeader(t, 11, stateRoot, nil)},
+		},
+	}
+
+	decodedHeaders, err := header.DecodeParachainHeaders()
+	require.NoError(t, err)
+	require.Len(t, decodedHeaders, 2)
+	for i, decodedHeader := range decodedHeaders {
+		require.Same(t, header.ConsensusStateUpdate.ParachainHeaders[i], decodedHeader.ParachainHeader)
+		require.Equal(t, rpcclienttypes.BlockNumber(10+i), decodedHeader.Header.Number)
+		require.Equal(t, int64(1643972151006), decodedHeader.Timestamp.UnixMilli())
+	}
+
+	// the decoded headers are cached
+	cachedHeaders, err := header.DecodeParachainHeaders()
+	require.NoError(t, err)
+	require.Same(t, &decodedHeaders[0], &cachedHeaders[0])
+
+	// replacing a parachain header discards the cache
+	header.ConsensusStateUpdate.ParachainHeaders[1] = newTestParachainHeader(t, 12, stateRoot, nil)
+	decodedHeaders, err = header.DecodeParachainHeaders()
+	require.NoError(t, err)
+	require.Equal(t, rpcclienttypes.BlockNumber(12), decodedHeaders[1].Header.Number)
+
+	height, err := header.Height()
+	require.NoError(t, err)
+	require.Equal(t, clienttypes.NewHeight(uint64(PARA_ID), 10), height)
+
+	_, err = (&beefytypes.Header{}).DecodeParachainHeaders()
+	require.ErrorIs(t, err, clienttypes.ErrInvalidHeader)
+}
+
 func hexify(bytes []byte) string {
 	res := make([]string, len(bytes))
 	for i, b := range bytes {
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"bytes"
 	"time"
 
+	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
 	"github.com/cosmos/ibc-go/v5/modules/core/exported"
@@ -13,16 +15,26 @@ var _ exported.Header = &Misbehaviour{}
 // FrozenHeight Use the same FrozenHeight for all misbehaviour
 var FrozenHeight = clienttypes.NewHeight(0, 1)
 
-// NewMisbehaviour creates a new Misbehaviour instance.
+// NewMisbehaviour creates a new Misbehaviour instance, proving an equivocation by the signed commitments of
+// the headers.
 func NewMisbehaviour(clientID string, header1, header2 *Header) *Misbehaviour {
 	return &Misbehaviour{
 		// TODO: clientid is deprecated, shall we use it ?
 		// ClientId: clientID,
 		Header1: header1,
 		Header2: header2,
+		Type:    MisbehaviourType_EQUIVOCATION,
 	}
 }
 
+// NewParachainForkMisbehaviour creates a new Misbehaviour instance, proving a parachain fork by the parachain
+// updates of the headers.
+func NewParachainForkMisbehaviour(clientID string, header1, header2 *Header) *Misbehaviour {
+	misbehaviour := NewMisbehaviour(clientID, header1, header2)
+	misbehaviour.Type = MisbehaviourType_PARACHAIN_FORK
+	return misbehaviour
+}
+
 // ClientType is Tendermint light client
 func (misbehaviour Misbehaviour) ClientType() string {
 	return Beefy
@@ -42,87 +54,120 @@ func (misbehaviour Misbehaviour) GetTime() time.Time {
 
 // ValidateBasic implements Misbehaviour interface
 func (misbehaviour Misbehaviour) ValidateBasic() error {
-	/*if misbehaviour.Header1 == nil {
-		return sdkerrors.Wrap(ErrInvalidHeader, "misbehaviour Header1 cannot be nil")
+	if misbehaviour.Header1 == nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour Header1 cannot be nil")
 	}
 	if misbehaviour.Header2 == nil {
-		return sdkerrors.Wrap(ErrInvalidHeader, "misbehaviour Header2 cannot be nil")
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour Header2 cannot be nil")
 	}
-	if misbehaviour.Header1.TrustedHeight.RevisionHeight == 0 {
-		return sdkerrors.Wrapf(ErrInvalidHeaderHeight, "misbehaviour Header1 cannot have zero revision height")
+
+	switch misbehaviour.Type {
+	case MisbehaviourType_EQUIVOCATION:
+		if misbehaviour.Header1.ClientState == nil || misbehaviour.Header2.ClientState == nil {
+			return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour headers must both contain a signed commitment")
+		}
+
+		return validateEquivocation(misbehaviour.Header1.ClientState.SignedCommitment, misbehaviour.Header2.ClientState.SignedCommitment)
+	case MisbehaviourType_PARACHAIN_FORK:
+		if misbehaviour.Header1.ConsensusStateUpdate == nil || misbehaviour.Header2.ConsensusStateUpdate == nil {
+			return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour headers must both contain a parachain update")
+		}
+
+		return validateParachainFork(misbehaviour.Header1.ConsensusStateUpdate, misbehaviour.Header2.ConsensusStateUpdate)
+	default:
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "unknown misbehaviour type %d", misbehaviour.Type)
 	}
-	if misbehaviour.Header2.TrustedHeight.RevisionHeight == 0 {
-		return sdkerrors.Wrapf(ErrInvalidHeaderHeight, "misbehaviour Header2 cannot have zero revision height")
+}
+
+// IsParachainFork returns true if the misbehaviour is evidence of two parachain headers finalized at the same
+// height, rather than of two conflicting signed commitments. Relayed headers usually carry both a signed
+// commitment and a parachain update, so the kind of evidence is given by the Type of the misbehaviour.
+func (misbehaviour Misbehaviour) IsParachainFork() bool {
+	return misbehaviour.Type == MisbehaviourType_PARACHAIN_FORK
+}
+
+// validateParachainFork checks that both updates contain a single parachain header, and that the headers
+// are for the same block number but have different hashes.
+func validateParachainFork(update1, update2 *ConsensusStateUpdateProof) error {
+	if len(update1.ParachainHeaders) != 1 || update1.ParachainHeaders[0] == nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "Header1 must contain exactly one parachain header")
 	}
-	if misbehaviour.Header1.TrustedValidators == nil {
-		return sdkerrors.Wrap(ErrInvalidValidatorSet, "trusted validator set in Header1 cannot be empty")
+	if len(update2.ParachainHeaders) != 1 || update2.ParachainHeaders[0] == nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "Header2 must contain exactly one parachain header")
 	}
-	if misbehaviour.Header2.TrustedValidators == nil {
-		return sdkerrors.Wrap(ErrInvalidValidatorSet, "trusted validator set in Header2 cannot be empty")
+
+	if update1.ParachainHeaders[0].ParaId == 0 || update2.ParachainHeaders[0].ParaId == 0 {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "parachain headers must have a para id")
 	}
-	if misbehaviour.Header1.Header.ChainID != misbehaviour.Header2.Header.ChainID {
-		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "headers must have identical chainIDs")
+	if update1.ParachainHeaders[0].ParaId != update2.ParachainHeaders[0].ParaId {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "parachain headers must be of the same parachain (%d != %d)",
+			update1.ParachainHeaders[0].ParaId, update2.ParachainHeaders[0].ParaId)
 	}
 
-	if err := host.ClientIdentifierValidator(misbehaviour.ClientId); err != nil {
-		return sdkerrors.Wrap(err, "misbehaviour client ID is invalid")
-	}
-	*/
-	// ValidateBasic on both validators
-	if err := misbehaviour.Header1.ValidateBasic(); err != nil {
-		return sdkerrors.Wrap(
-			clienttypes.ErrInvalidMisbehaviour,
-			sdkerrors.Wrap(err, "header 1 failed validation").Error(),
-		)
+	header1, err := DecodeParachainHeader(update1.ParachainHeaders[0].ParachainHeader)
+	if err != nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, sdkerrors.Wrap(err, "failed to decode parachain header 1").Error())
 	}
-	if err := misbehaviour.Header2.ValidateBasic(); err != nil {
-		return sdkerrors.Wrap(
-			clienttypes.ErrInvalidMisbehaviour,
-			sdkerrors.Wrap(err, "header 2 failed validation").Error(),
-		)
+	header2, err := DecodeParachainHeader(update2.ParachainHeaders[0].ParachainHeader)
+	if err != nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, sdkerrors.Wrap(err, "failed to decode parachain header 2").Error())
 	}
-	// Ensure that Height1 is greater than or equal to Height2
-	if misbehaviour.Header1.GetHeight().LT(misbehaviour.Header2.GetHeight()) {
-		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "Header1 height is less than Header2 height (%s < %s)", misbehaviour.Header1.GetHeight(), misbehaviour.Header2.GetHeight())
+
+	if header1.Number != header2.Number {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "parachain headers must have the same block number (%d != %d)",
+			header1.Number, header2.Number)
 	}
 
-	/*blockID1, err := tmtypes.BlockIDFromProto(&misbehaviour.Header1.SignedHeader.Commit.BlockID)
+	// compare the hashes of the re-encoded headers, so the evidence can't be forged by padding the encoding
+	hash1, err := rpcclienttypes.GetHash(header1)
 	if err != nil {
-		return sdkerrors.Wrap(err, "invalid block ID from header 1 in misbehaviour")
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, err.Error())
 	}
-	blockID2, err := tmtypes.BlockIDFromProto(&misbehaviour.Header2.SignedHeader.Commit.BlockID)
+	hash2, err := rpcclienttypes.GetHash(header2)
 	if err != nil {
-		return sdkerrors.Wrap(err, "invalid block ID from header 2 in misbehaviour")
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, err.Error())
 	}
-
-	if err := validCommit(misbehaviour.Header1.Header.ChainID, *blockID1,
-		misbehaviour.Header1.Commit, misbehaviour.Header1.ValidatorSet); err != nil {
-		return err
+	if hash1 == hash2 {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "parachain headers at height %d must have different hashes", header1.Number)
 	}
-	if err := validCommit(misbehaviour.Header2.Header.ChainID, *blockID2,
-		misbehaviour.Header2.Commit, misbehaviour.Header2.ValidatorSet); err != nil {
-		return err
-	}*/
+
 	return nil
 }
 
-// validCommit checks if the given commit is a valid commit from the passed-in validatorset
-/*func validCommit(chainID string, blockID tmtypes.BlockID, commit *tmproto.Commit, valSet *tmproto.ValidatorSet) (err error) {
-	tmCommit, err := tmtypes.CommitFromProto(commit)
-	if err != nil {
-		return sdkerrors.Wrap(err, "commit is not tendermint commit type")
+// validateEquivocation checks that both signed commitments are for the same block and authority set,
+// but commit to a different mmr root hash.
+func validateEquivocation(signedCommitment1, signedCommitment2 *SignedCommitment) error {
+	if signedCommitment1 == nil || signedCommitment1.Commitment == nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "signed commitment 1 cannot be empty")
 	}
-	tmValset, err := tmtypes.ValidatorSetFromProto(valSet)
-	if err != nil {
-		return sdkerrors.Wrap(err, "validator set is not tendermint validator set type")
+	if signedCommitment2 == nil || signedCommitment2.Commitment == nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "signed commitment 2 cannot be empty")
 	}
 
-	if err := tmValset.VerifyCommitLight(chainID, blockID, tmCommit.Height, tmCommit); err != nil {
-		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "validator set did not commit to header")
+	commitment1, commitment2 := signedCommitment1.Commitment, signedCommitment2.Commitment
+	if commitment1.BlockNumer != commitment2.BlockNumer {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "commitments must be for the same block number (%d != %d)",
+			commitment1.BlockNumer, commitment2.BlockNumer)
+	}
+	if commitment1.ValidatorSetId != commitment2.ValidatorSetId {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidMisbehaviour, "commitments must be signed by the same validator set (%d != %d)",
+			commitment1.ValidatorSetId, commitment2.ValidatorSetId)
+	}
+
+	mmrRoot1, ok := mmrRootPayload(commitment1)
+	if !ok {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "commitment 1 does not contain an mmr root hash")
+	}
+	mmrRoot2, ok := mmrRootPayload(commitment2)
+	if !ok {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "commitment 2 does not contain an mmr root hash")
+	}
+	if bytes.Equal(mmrRoot1, mmrRoot2) {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "commitments must have different mmr root hashes")
 	}
 
 	return nil
-}*/
+}
 
 // GetHeight implements the current exported.Header interface, to be updated
 // TODO: Remove GetHeight()
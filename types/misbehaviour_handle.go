@@ -0,0 +1,70 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+)
+
+// verifyMisbehaviour determines whether or not the misbehaviour is valid evidence of either a beefy
+// equivocation or a parachain fork, according to its Type. It returns an error if:
+//   - the misbehaviour fails basic validation
+//   - the signed commitments of an equivocation aren't signed by a supermajority of a known authority set
+//   - the parachain headers of a fork can't be proven to be included in the mmr
+func (cs *ClientState) verifyMisbehaviour(
+	ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec,
+	misbehaviour *Misbehaviour,
+) error {
+	if err := misbehaviour.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if misbehaviour.IsParachainFork() {
+		for i, header := range []*Header{misbehaviour.Header1, misbehaviour.Header2} {
+			if err := cs.verifyForkedParachainHeader(header); err != nil {
+				return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, sdkerrors.Wrapf(err, "failed to verify parachain header %d", i+1).Error())
+			}
+		}
+
+		return nil
+	}
+
+	// both commitments must be finalized by the known authorities for the misbehaviour to be valid
+	if _, err := cs.verifySignedCommitment(
+		misbehaviour.Header1.ClientState.SignedCommitment, misbehaviour.Header1.ClientState.AuthoritiesProof,
+	); err != nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, sdkerrors.Wrap(err, "failed to verify signed commitment 1").Error())
+	}
+	if _, err := cs.verifySignedCommitment(
+		misbehaviour.Header2.ClientState.SignedCommitment, misbehaviour.Header2.ClientState.AuthoritiesProof,
+	); err != nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, sdkerrors.Wrap(err, "failed to verify signed commitment 2").Error())
+	}
+
+	return nil
+}
+
+// verifyForkedParachainHeader proves the parachain header of one side of a fork into the mmr. The mmr root
+// is the one known to the client, unless the header carries a signed commitment to a different mmr root.
+func (cs ClientState) verifyForkedParachainHeader(header *Header) error {
+	mmrRoot := cs.MmrRootHash
+
+	if header.ClientState != nil {
+		if _, err := cs.verifySignedCommitment(header.ClientState.SignedCommitment, header.ClientState.AuthoritiesProof); err != nil {
+			return err
+		}
+
+		signedMmrRoot, ok := mmrRootPayload(header.ClientState.SignedCommitment.Commitment)
+		if !ok {
+			return sdkerrors.Wrap(ErrInvalidCommitment, "commitment does not contain an mmr root hash")
+		}
+		mmrRoot = signedMmrRoot
+	}
+
+	if len(mmrRoot) == 0 {
+		return sdkerrors.Wrap(ErrInvalidRootHash, "no mmr root hash to prove the parachain header against")
+	}
+
+	return cs.verifyParachainHeaders(header, mmrRoot)
+}
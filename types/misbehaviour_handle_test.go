@@ -0,0 +1,522 @@
+package types_test
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ComposableFi/go-merkle-trees/hasher"
+	"github.com/ComposableFi/go-merkle-trees/merkle"
+	"github.com/ComposableFi/go-merkle-trees/mmr"
+	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
+)
+
+func TestVerifyEquivocationMisbehaviour(t *testing.T) {
+	authorities := newTestAuthorities(t, 4)
+	nextAuthorities := newTestAuthorities(t, 4)
+
+	newClientState := func() *beefytypes.ClientState {
+		return &beefytypes.ClientState{
+			LatestBeefyHeight: 100,
+			ParaId:            PARA_ID,
+			Authority:         authorities.authoritySet(1),
+			NextAuthoritySet:  nextAuthorities.authoritySet(2),
+		}
+	}
+
+	commitment := func(blockNumber uint32, setID uint64, mmrRoot string) *beefytypes.Commitment {
+		return &beefytypes.Commitment{
+			Payload: []*beefytypes.PayloadItem{
+				{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: crypto.Keccak256([]byte(mmrRoot))},
+			},
+			BlockNumer:     blockNumber,
+			ValidatorSetId: setID,
+		}
+	}
+
+	allSigners := []uint32{0, 1, 2, 3}
+
+	// relayed headers carry the parachain updates of the commitment along with it
+	withParachainUpdate := func(header *beefytypes.Header, stateRoots ...string) *beefytypes.Header {
+		update := &beefytypes.ConsensusStateUpdateProof{}
+		for _, stateRoot := range stateRoots {
+			update.ParachainHeaders = append(update.ParachainHeaders,
+				newTestParachainHeader(t, 10, bytes32(crypto.Keccak256([]byte(stateRoot))), []byte("ibc root")))
+		}
+		header.ConsensusStateUpdate = update
+		return header
+	}
+
+	testCases := []struct {
+		name    string
+		header1 *beefytypes.Header
+		header2 *beefytypes.Header
+		expPass bool
+	}{
+		{
+			"valid equivocation by the current authority set",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			authorities.signedHeader(t, commitment(90, 1, "fork b"), []uint32{0, 1, 3}),
+			true,
+		},
+		{
+			"valid equivocation by the next authority set",
+			nextAuthorities.signedHeader(t, commitment(110, 2, "fork a"), allSigners),
+			nextAuthorities.signedHeader(t, commitment(110, 2, "fork b"), allSigners),
+			true,
+		},
+		{
+			"same mmr root hash",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), []uint32{0, 1, 3}),
+			false,
+		},
+		{
+			"different block numbers",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			authorities.signedHeader(t, commitment(91, 1, "fork b"), allSigners),
+			false,
+		},
+		{
+			"different validator set ids",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			nextAuthorities.signedHeader(t, commitment(90, 2, "fork b"), allSigners),
+			false,
+		},
+		{
+			"no supermajority",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			authorities.signedHeader(t, commitment(90, 1, "fork b"), []uint32{0, 1}),
+			false,
+		},
+		{
+			"signed by unknown authorities",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			newTestAuthorities(t, 4).signedHeader(t, commitment(90, 1, "fork b"), allSigners),
+			false,
+		},
+		{
+			"unknown authority set",
+			authorities.signedHeader(t, commitment(90, 3, "fork a"), allSigners),
+			authorities.signedHeader(t, commitment(90, 3, "fork b"), allSigners),
+			false,
+		},
+		{
+			"valid equivocation of headers with parachain updates",
+			withParachainUpdate(authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners), "a", "b"),
+			withParachainUpdate(authorities.signedHeader(t, commitment(90, 1, "fork b"), allSigners), "a"),
+			true,
+		},
+		{
+			"missing signed commitment",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			&beefytypes.Header{},
+			false,
+		},
+		{
+			"missing signed commitment of a header with a parachain update",
+			authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+			withParachainUpdate(&beefytypes.Header{}, "a"),
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cdc, clientStore := newTestClientStore(t)
+			clientState := newClientState()
+
+			misbehaviour := beefytypes.NewMisbehaviour("", tc.header1, tc.header2)
+			err := clientState.VerifyClientMessage(ctx, cdc, clientStore, misbehaviour)
+			if !tc.expPass {
+				require.ErrorIs(t, err, clienttypes.ErrInvalidMisbehaviour)
+				return
+			}
+			require.NoError(t, err)
+
+			require.True(t, clientState.CheckForMisbehaviour(ctx, cdc, clientStore, misbehaviour))
+			clientState.UpdateStateOnMisbehaviour(ctx, cdc, clientStore, misbehaviour)
+			require.Equal(t, exported.Frozen, clientState.Status(ctx, clientStore, cdc))
+		})
+	}
+
+	// evidence of an unknown kind is rejected whatever its headers contain
+	misbehaviour := beefytypes.NewMisbehaviour("",
+		authorities.signedHeader(t, commitment(90, 1, "fork a"), allSigners),
+		authorities.signedHeader(t, commitment(90, 1, "fork b"), allSigners))
+	misbehaviour.Type = 2
+	require.ErrorIs(t, misbehaviour.ValidateBasic(), clienttypes.ErrInvalidMisbehaviour)
+}
+
+// testSigners is an authority set whose authorities sign commitments in tests.
+type testSigners interface {
+	authoritySet(id uint64) *beefytypes.BeefyAuthoritySet
+	signedHeader(t *testing.T, commitment *beefytypes.Commitment, signers []uint32) *beefytypes.Header
+}
+
+// testKey is the secret key of an authority, for one of the signature schemes of the client.
+type testKey interface {
+	// leaf returns the hash of the authority in the authority merkle tree.
+	leaf() []byte
+	// sign adds the signature of the commitment hash by the authority at the given index to the signed commitment.
+	sign(t *testing.T, commitmentHash []byte, index uint32, signedCommitment *beefytypes.SignedCommitment)
+}
+
+// testAuthorities are the keys of a beefy authority set.
+type testAuthorities[K testKey] []K
+
+var (
+	_ testSigners = testAuthorities[ecdsaTestKey]{}
+	_ testSigners = testAuthorities[blsTestKey]{}
+)
+
+// generateTestAuthorities returns an authority set of n keys generated by newKey.
+func generateTestAuthorities[K testKey](t *testing.T, n int, newKey func(t *testing.T) K) testAuthorities[K] {
+	t.Helper()
+
+	var authorities testAuthorities[K]
+	for i := 0; i < n; i++ {
+		authorities = append(authorities, newKey(t))
+	}
+
+	return authorities
+}
+
+// newTestAuthorities returns an authority set of n ecdsa keys.
+func newTestAuthorities(t *testing.T, n int) testAuthorities[ecdsaTestKey] {
+	return generateTestAuthorities(t, n, newECDSATestKey)
+}
+
+func (a testAuthorities[K]) tree() merkle.Tree {
+	var leaves [][]byte
+	for _, key := range a {
+		leaves = append(leaves, key.leaf())
+	}
+
+	tree, err := merkle.NewTree(hasher.Keccak256Hasher{}).FromLeaves(leaves)
+	if err != nil {
+		panic(err)
+	}
+
+	return tree
+}
+
+func (a testAuthorities[K]) authoritySet(id uint64) *beefytypes.BeefyAuthoritySet {
+	tree := a.tree()
+	root := bytes32(tree.Root())
+	return &beefytypes.BeefyAuthoritySet{
+		Id:            id,
+		Len:           uint32(len(a)),
+		AuthorityRoot: &root,
+	}
+}
+
+// signedHeader returns a Header with the commitment signed by the authorities at the given indices.
+func (a testAuthorities[K]) signedHeader(t *testing.T, commitment *beefytypes.Commitment, signers []uint32) *beefytypes.Header {
+	t.Helper()
+
+	commitmentBytes, err := rpcclienttypes.Encode(commitment)
+	require.NoError(t, err)
+	commitmentHash := crypto.Keccak256(commitmentBytes)
+
+	signedCommitment := &beefytypes.SignedCommitment{Commitment: commitment}
+	var indices []uint64
+	for _, index := range signers {
+		a[index].sign(t, commitmentHash, index, signedCommitment)
+		indices = append(indices, uint64(index))
+	}
+
+	tree := a.tree()
+	proof := tree.Proof(indices)
+
+	return &beefytypes.Header{
+		ClientState: &beefytypes.ClientStateUpdateProof{
+			SignedCommitment: signedCommitment,
+			AuthoritiesProof: proof.ProofHashes(),
+		},
+	}
+}
+
+// ecdsaTestKey is the secp256k1 key of an authority, whose leaf is the hash of its ethereum address.
+type ecdsaTestKey struct {
+	*ecdsa.PrivateKey
+}
+
+func newECDSATestKey(t *testing.T) ecdsaTestKey {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return ecdsaTestKey{key}
+}
+
+func (k ecdsaTestKey) leaf() []byte {
+	address := crypto.PubkeyToAddress(k.PublicKey)
+	return crypto.Keccak256(address[:])
+}
+
+func (k ecdsaTestKey) sign(t *testing.T, commitmentHash []byte, index uint32, signedCommitment *beefytypes.SignedCommitment) {
+	signature, err := crypto.Sign(commitmentHash, k.PrivateKey)
+	require.NoError(t, err)
+
+	signedCommitment.Signatures = append(signedCommitment.Signatures, &beefytypes.CommitmentSignature{
+		Signature:      signature,
+		AuthorityIndex: index,
+	})
+}
+
+func TestVerifyParachainForkMisbehaviour(t *testing.T) {
+	authorities := newTestAuthorities(t, 4)
+
+	header := func(number uint32, stateRoot string) *beefytypes.ParachainHeader {
+		return newTestParachainHeader(t, number, bytes32(crypto.Keccak256([]byte(stateRoot))), []byte("ibc root"))
+	}
+
+	otherParachainHeader := header(10, "fork c")
+	otherParachainHeader.ParaId = PARA_ID + 1
+
+	// the fork is included in the mmr at leaves 3 and 5, with unrelated headers at leaves 6 and 7
+	mmrRoot, headers := newTestMMRHeaders(t, 8, map[uint32]*beefytypes.ParachainHeader{
+		3: header(10, "fork a"),
+		5: header(10, "fork b"),
+		6: header(11, "fork b"),
+		7: otherParachainHeader,
+	})
+
+	newClientState := func() *beefytypes.ClientState {
+		return &beefytypes.ClientState{
+			LatestBeefyHeight: 100,
+			MmrRootHash:       mmrRoot,
+			ParaId:            PARA_ID,
+			Authority:         authorities.authoritySet(1),
+			NextAuthoritySet:  authorities.authoritySet(2),
+		}
+	}
+
+	withSignedCommitment := func(header *beefytypes.Header, mmrRoot []byte) *beefytypes.Header {
+		signedHeader := authorities.signedHeader(t, &beefytypes.Commitment{
+			Payload:        []*beefytypes.PayloadItem{{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: mmrRoot}},
+			BlockNumer:     90,
+			ValidatorSetId: 1,
+		}, []uint32{0, 1, 2, 3})
+		signedHeader.ConsensusStateUpdate = header.ConsensusStateUpdate
+		return signedHeader
+	}
+
+	withoutParaId := func(header *beefytypes.Header) *beefytypes.Header {
+		parachainHeader := *header.ConsensusStateUpdate.ParachainHeaders[0]
+		parachainHeader.ParaId = 0
+		update := *header.ConsensusStateUpdate
+		update.ParachainHeaders = []*beefytypes.ParachainHeader{&parachainHeader}
+		return &beefytypes.Header{ConsensusStateUpdate: &update}
+	}
+
+	testCases := []struct {
+		name     string
+		header1  *beefytypes.Header
+		header2  *beefytypes.Header
+		malleate func(clientState *beefytypes.ClientState)
+		expPass  bool
+	}{
+		{
+			"valid fork",
+			headers[3], headers[5], nil, true,
+		},
+		{
+			"only one side proven against a signed mmr root",
+			withSignedCommitment(headers[3], mmrRoot), headers[5],
+			func(clientState *beefytypes.ClientState) {
+				clientState.MmrRootHash = crypto.Keccak256([]byte("other mmr root"))
+			},
+			false,
+		},
+		{
+			"fork proven against the signed mmr roots",
+			withSignedCommitment(headers[3], mmrRoot), withSignedCommitment(headers[5], mmrRoot),
+			func(clientState *beefytypes.ClientState) {
+				clientState.MmrRootHash = crypto.Keccak256([]byte("other mmr root"))
+			},
+			true,
+		},
+		{
+			"different block numbers",
+			headers[3], headers[6], nil, false,
+		},
+		{
+			"different parachains",
+			headers[3], headers[7],
+			func(clientState *beefytypes.ClientState) {
+				clientState.AdditionalParachains = newTestParachains(PARA_ID + 1)
+			},
+			false,
+		},
+		{
+			"missing para id",
+			headers[3], withoutParaId(headers[5]), nil, false,
+		},
+		{
+			"same header",
+			headers[3], headers[3], nil, false,
+		},
+		{
+			"headers not in the mmr",
+			headers[3], headers[5],
+			func(clientState *beefytypes.ClientState) {
+				clientState.MmrRootHash = crypto.Keccak256([]byte("other mmr root"))
+			},
+			false,
+		},
+		{
+			"signed commitment to a different mmr root",
+			withSignedCommitment(headers[3], crypto.Keccak256([]byte("other mmr root"))), headers[5], nil, false,
+		},
+		{
+			"missing parachain update",
+			headers[3], withSignedCommitment(&beefytypes.Header{}, mmrRoot), nil, false,
+		},
+		{
+			"more than one parachain header",
+			&beefytypes.Header{ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+				ParachainHeaders: []*beefytypes.ParachainHeader{header(10, "fork a"), header(10, "fork a")},
+			}},
+			headers[5], nil, false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cdc, clientStore := newTestClientStore(t)
+			clientState := newClientState()
+			if tc.malleate != nil {
+				tc.malleate(clientState)
+			}
+
+			misbehaviour := beefytypes.NewParachainForkMisbehaviour("", tc.header1, tc.header2)
+			err := clientState.VerifyClientMessage(ctx, cdc, clientStore, misbehaviour)
+			if !tc.expPass {
+				require.ErrorIs(t, err, clienttypes.ErrInvalidMisbehaviour)
+				return
+			}
+			require.NoError(t, err)
+
+			require.True(t, clientState.CheckForMisbehaviour(ctx, cdc, clientStore, misbehaviour))
+			clientState.UpdateStateOnMisbehaviour(ctx, cdc, clientStore, misbehaviour)
+			require.Equal(t, exported.Frozen, clientState.Status(ctx, clientStore, cdc))
+		})
+	}
+}
+
+func TestCheckForMisbehaviourDuplicateHeight(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, ParaId: PARA_ID}
+
+	stored := &beefytypes.Header{ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+		ParachainHeaders: []*beefytypes.ParachainHeader{
+			newTestParachainHeader(t, 10, bytes32(crypto.Keccak256([]byte("fork a"))), []byte("ibc root a")),
+		},
+	}}
+	require.False(t, clientState.CheckForMisbehaviour(ctx, cdc, clientStore, stored))
+	clientState.UpdateState(ctx, cdc, clientStore, stored)
+
+	// a conflicting consensus state at a known height is not enough evidence to freeze the client
+	conflicting := &beefytypes.Header{ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+		ParachainHeaders: []*beefytypes.ParachainHeader{
+			newTestParachainHeader(t, 10, bytes32(crypto.Keccak256([]byte("fork b"))), []byte("ibc root b")),
+		},
+	}}
+	require.False(t, clientState.CheckForMisbehaviour(ctx, cdc, clientStore, conflicting))
+
+	// but a header with the same timestamp at a later height violates monotonic time
+	later := &beefytypes.Header{ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+		ParachainHeaders: []*beefytypes.ParachainHeader{
+			newTestParachainHeader(t, 11, bytes32(crypto.Keccak256([]byte("fork a"))), []byte("ibc root a")),
+		},
+	}}
+	require.True(t, clientState.CheckForMisbehaviour(ctx, cdc, clientStore, later))
+}
+
+// newTestMMRHeaders builds an mmr with leafCount leaves, in which the given parachain headers are included
+// at their leaf index. It returns the mmr root along with a Header proving each parachain header into the mmr.
+func newTestMMRHeaders(
+	t *testing.T, leafCount uint32, parachainHeaders map[uint32]*beefytypes.ParachainHeader,
+) ([]byte, map[uint32]*beefytypes.Header) {
+	t.Helper()
+
+	store := mmr.NewMemStore()
+	mmrTree := mmr.NewMMR(0, store, nil, hasher.Keccak256Hasher{})
+	for leafIndex := uint32(0); leafIndex < leafCount; leafIndex++ {
+		leafHash := crypto.Keccak256([]byte{byte(leafIndex)})
+
+		if parachainHeader, ok := parachainHeaders[leafIndex]; ok {
+			leafHash = newTestMMRLeafHash(t, leafIndex, parachainHeader)
+		}
+
+		_, err := mmrTree.Push(leafHash)
+		require.NoError(t, err)
+	}
+	mmrTree.Commit()
+
+	root, err := mmrTree.Root()
+	require.NoError(t, err)
+
+	headers := make(map[uint32]*beefytypes.Header)
+	for leafIndex, parachainHeader := range parachainHeaders {
+		proof, err := mmrTree.GenProof([]uint64{mmr.LeafIndexToPos(uint64(leafIndex))})
+		require.NoError(t, err)
+
+		headers[leafIndex] = &beefytypes.Header{
+			ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+				ParachainHeaders: []*beefytypes.ParachainHeader{parachainHeader},
+				MmrProofs:        proof.ProofItems(),
+				MmrSize:          mmrTree.MMRSize(),
+			},
+		}
+	}
+
+	return root, headers
+}
+
+// newTestMMRLeafHash fills in the mmr leaf data of the parachain header, for an mmr leaf at the given index
+// whose parachain heads only contain this header, and returns the hash of that mmr leaf.
+func newTestMMRLeafHash(t *testing.T, leafIndex uint32, parachainHeader *beefytypes.ParachainHeader) []byte {
+	t.Helper()
+
+	headsLeaf, err := rpcclienttypes.Encode(beefytypes.ParaIdAndHeader{ParaId: parachainHeader.ParaId, Header: parachainHeader.ParachainHeader})
+	require.NoError(t, err)
+	headsTree, err := merkle.NewTree(hasher.Keccak256Hasher{}).FromLeaves([][]byte{crypto.Keccak256(headsLeaf)})
+	require.NoError(t, err)
+
+	parentHash := bytes32(crypto.Keccak256([]byte("parent hash")))
+	authorityRoot := bytes32(crypto.Keccak256([]byte("authority root")))
+	parachainHeader.MmrLeafPartial = &beefytypes.BeefyMmrLeafPartial{
+		// with beefy active from genesis, the leaf index is the parent number
+		ParentNumber: leafIndex,
+		ParentHash:   &parentHash,
+		BeefyNextAuthoritySet: beefytypes.BeefyAuthoritySet{
+			Id:            2,
+			Len:           4,
+			AuthorityRoot: &authorityRoot,
+		},
+	}
+	parachainHeader.HeadsLeafIndex = 0
+	parachainHeader.HeadsTotalCount = 1
+
+	parachainHeads := bytes32(headsTree.Root())
+	mmrLeaf, err := rpcclienttypes.Encode(beefytypes.BeefyMmrLeaf{
+		Version:               parachainHeader.MmrLeafPartial.Version,
+		ParentNumber:          parachainHeader.MmrLeafPartial.ParentNumber,
+		ParentHash:            parachainHeader.MmrLeafPartial.ParentHash,
+		BeefyNextAuthoritySet: parachainHeader.MmrLeafPartial.BeefyNextAuthoritySet,
+		ParachainHeads:        &parachainHeads,
+	})
+	require.NoError(t, err)
+
+	return crypto.Keccak256(mmrLeaf)
+}
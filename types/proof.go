@@ -0,0 +1,266 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ComposableFi/go-substrate-rpc-client/v4/scale"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	commitmenttypes "github.com/cosmos/ibc-go/v5/modules/core/23-commitment/types"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+)
+
+// verifyTrieMembership asserts that the substrate trie proof commits to the value under the given key.
+func verifyTrieMembership(proof [][]byte, root, key, value []byte) error {
+	trieValue, found, err := readTrieProof(proof, root, key)
+	if err != nil {
+		return sdkerrors.Wrap(commitmenttypes.ErrInvalidProof, err.Error())
+	}
+
+	if !found || !bytes.Equal(trieValue, value) {
+		return sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "value for key %x does not match the value committed in the trie", key)
+	}
+
+	return nil
+}
+
+// verifyTrieNonMembership asserts that the substrate trie proof shows that no value is stored under the given key.
+func verifyTrieNonMembership(proof [][]byte, root, key []byte) error {
+	_, found, err := readTrieProof(proof, root, key)
+	if err != nil {
+		return sdkerrors.Wrap(commitmenttypes.ErrInvalidProof, err.Error())
+	}
+
+	if found {
+		return sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "a value for key %x is committed in the trie", key)
+	}
+
+	return nil
+}
+
+const (
+	trieNodeLeaf            = 0b01
+	trieNodeBranch          = 0b10
+	trieNodeBranchWithValue = 0b11
+	// partial key lengths of 63 nibbles or more are continued in the following bytes
+	triePartialKeyLenMask = 0x3f
+)
+
+// trieNode is a decoded substrate trie node, children are either the hash of the child node,
+// or the encoding of the child itself when it is shorter than a hash.
+type trieNode struct {
+	partialKey []byte
+	value      []byte
+	hasValue   bool
+	isBranch   bool
+	children   [16][]byte
+}
+
+// readTrieProof walks the nodes of a substrate trie proof from the root down to the key, and returns the
+// value stored under it. found is false when the proof shows that the key is not in the trie. Unlike the
+// partial trie of the trie lib, which treats the nodes that are missing from the proof as empty, an
+// error is returned if the path to the key leaves the proof.
+func readTrieProof(proof [][]byte, root, key []byte) (value []byte, found bool, err error) {
+	nodes := make(map[string][]byte, len(proof))
+	for _, encodedNode := range proof {
+		hash, err := common.Blake2bHash(encodedNode)
+		if err != nil {
+			return nil, false, err
+		}
+		nodes[string(hash[:])] = encodedNode
+	}
+
+	encodedNode, ok := nodes[string(root)]
+	if !ok {
+		return nil, false, fmt.Errorf("root node %x not found in proof", root)
+	}
+
+	nibbles := keyToNibbles(key)
+	for {
+		node, err := decodeTrieNode(encodedNode)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// the empty trie
+		if node == nil {
+			return nil, false, nil
+		}
+
+		if !bytes.HasPrefix(nibbles, node.partialKey) {
+			return nil, false, nil
+		}
+		nibbles = nibbles[len(node.partialKey):]
+
+		if len(nibbles) == 0 {
+			return node.value, node.hasValue, nil
+		}
+
+		if !node.isBranch {
+			return nil, false, nil
+		}
+
+		child := node.children[nibbles[0]]
+		nibbles = nibbles[1:]
+		switch {
+		case child == nil:
+			return nil, false, nil
+		case len(child) < common.HashLength:
+			// inlined node
+			encodedNode = child
+		default:
+			encodedNode, ok = nodes[string(child)]
+			if !ok {
+				return nil, false, fmt.Errorf("node %x not found in proof", child)
+			}
+		}
+	}
+}
+
+// decodeTrieNode decodes a substrate trie node, it returns nil for the empty node.
+func decodeTrieNode(encodedNode []byte) (*trieNode, error) {
+	if len(encodedNode) == 0 {
+		return nil, fmt.Errorf("trie node cannot be empty")
+	}
+
+	decoder := scale.NewDecoder(bytes.NewReader(encodedNode))
+
+	header, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &trieNode{}
+	switch header >> 6 {
+	case trieNodeLeaf:
+		node.hasValue = true
+	case trieNodeBranch:
+		node.isBranch = true
+	case trieNodeBranchWithValue:
+		node.isBranch, node.hasValue = true, true
+	default:
+		if header == 0 && len(encodedNode) == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unknown trie node header %x", header)
+	}
+
+	keyLen := int(header & triePartialKeyLenMask)
+	if keyLen == triePartialKeyLenMask {
+		for {
+			next, err := decoder.ReadOneByte()
+			if err != nil {
+				return nil, err
+			}
+			keyLen += int(next)
+			if next < 0xff {
+				break
+			}
+		}
+	}
+
+	// the partial key is packed in nibbles, an odd length is padded with a leading zero nibble
+	packedKey := make([]byte, (keyLen+1)/2)
+	if err := decoder.Read(packedKey); err != nil {
+		return nil, fmt.Errorf("cannot read partial key: %w", err)
+	}
+	node.partialKey = keyToNibbles(packedKey)[keyLen%2:]
+
+	var bitmap uint16
+	if node.isBranch {
+		bitmapBytes := make([]byte, 2)
+		if err := decoder.Read(bitmapBytes); err != nil {
+			return nil, fmt.Errorf("cannot read children bitmap: %w", err)
+		}
+		bitmap = binary.LittleEndian.Uint16(bitmapBytes)
+	}
+
+	if node.hasValue {
+		if err := decoder.Decode(&node.value); err != nil {
+			return nil, fmt.Errorf("cannot decode value: %w", err)
+		}
+	}
+
+	for i := 0; i < 16; i++ {
+		if bitmap&(1<<i) == 0 {
+			continue
+		}
+		if err := decoder.Decode(&node.children[i]); err != nil {
+			return nil, fmt.Errorf("cannot decode child %d: %w", i, err)
+		}
+		if len(node.children[i]) == 0 {
+			return nil, fmt.Errorf("child %d cannot be empty", i)
+		}
+	}
+
+	return node, nil
+}
+
+// keyToNibbles splits every byte of the key into its high and low nibble.
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+// BeefyProof proves a key in the ibc state of the parachain. pallet-ibc keeps its state in a child trie,
+// so when the commitment root is the parachain state root, the root of the child trie is first proven under
+// it, and the key is then proven inside the child trie. When the commitment root is the child trie root
+// deposited in the /IBC digest, only the proof of the key in the child trie is used.
+type BeefyProof struct {
+	// proof of the child trie root in the parachain state trie
+	ChildTrieRootProof [][]byte
+	// proof of the key in the child trie
+	ChildTrieProof [][]byte
+}
+
+// childTrieRoot returns the root of the child trie stored under the commitment prefix. found is false
+// when the proof shows that there is no such child trie.
+func (p BeefyProof) childTrieRoot(rootSource RootSource, root []byte, prefix exported.Prefix) (childRoot []byte, found bool, err error) {
+	if rootSource == RootSource_IBC_DIGEST {
+		return root, true, nil
+	}
+
+	childTrieKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), prefix.Bytes()...)
+
+	childRoot, found, err = readTrieProof(p.ChildTrieRootProof, root, childTrieKey)
+	if err != nil {
+		return nil, false, sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "child trie root proof failed: %s", err)
+	}
+
+	return childRoot, found, nil
+}
+
+// VerifyMembership verifies that the value is stored under the key in the child trie of the commitment prefix,
+// the root is the commitment root of a consensus state with the given source.
+func (p BeefyProof) VerifyMembership(rootSource RootSource, root []byte, prefix exported.Prefix, key, value []byte) error {
+	childRoot, found, err := p.childTrieRoot(rootSource, root, prefix)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "child trie %x does not exist", prefix.Bytes())
+	}
+
+	return verifyTrieMembership(p.ChildTrieProof, childRoot, key, value)
+}
+
+// VerifyNonMembership verifies that no value is stored under the key in the child trie of the commitment prefix,
+// the root is the commitment root of a consensus state with the given source.
+func (p BeefyProof) VerifyNonMembership(rootSource RootSource, root []byte, prefix exported.Prefix, key []byte) error {
+	childRoot, found, err := p.childTrieRoot(rootSource, root, prefix)
+	if err != nil {
+		return err
+	}
+	// nothing is stored in a child trie which doesn't exist
+	if !found {
+		return nil
+	}
+
+	return verifyTrieNonMembership(p.ChildTrieProof, childRoot, key)
+}
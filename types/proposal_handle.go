@@ -0,0 +1,120 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+)
+
+// CheckSubstituteAndUpdateState will try to update the client with the state of the
+// substitute.
+//
+// The following must always be true:
+//   - The substitute client is the same type as the subject client
+//   - The subject and substitute client states track the same relay chain, parachain and beefy activation block,
+//     take the roots of their consensus states from the same source and verify commitments with the same
+//     signature scheme
+//
+// The subject client is unfrozen, and takes over the authority sets, mmr root, latest heights, tracked
+// parachains, commitment prefix and timestamp pallet index of the substitute, along with all of its consensus
+// states and their processed metadata.
+func (cs *ClientState) CheckSubstituteAndUpdateState(
+	ctx sdk.Context, cdc codec.BinaryCodec, subjectClientStore,
+	substituteClientStore sdk.KVStore, substituteClient exported.ClientState,
+) (exported.ClientState, error) {
+	substituteClientState, ok := substituteClient.(*ClientState)
+	if !ok {
+		return nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidClient, "expected type %T, got %T", &ClientState{}, substituteClient,
+		)
+	}
+
+	if !IsMatchingClientState(*cs, *substituteClientState) {
+		return nil, sdkerrors.Wrap(clienttypes.ErrInvalidSubstitute, "subject client state does not match substitute client state")
+	}
+
+	// the processed metadata is copied verbatim, so it is kept in its stored encoding
+	type consensusStateWithMetadata struct {
+		height          exported.Height
+		consensusState  *ConsensusState
+		processedHeight []byte
+		processedTime   []byte
+	}
+
+	// read every consensus state of the substitute before writing anything,
+	// so that a missing entry leaves the subject client store untouched.
+	var (
+		consensusStates []consensusStateWithMetadata
+		err             error
+	)
+	IterateConsensusStateAscending(substituteClientStore, func(height exported.Height) bool {
+		entry := consensusStateWithMetadata{height: height}
+
+		entry.consensusState, err = GetConsensusState(substituteClientStore, cdc, height)
+		if err != nil {
+			err = sdkerrors.Wrap(err, "unable to retrieve consensus state for substitute client")
+			return true
+		}
+
+		entry.processedHeight = substituteClientStore.Get(ProcessedHeightKey(height))
+		if entry.processedHeight == nil {
+			err = sdkerrors.Wrapf(ErrProcessedHeightNotFound, "processed height not found for substitute consensus state at height %s", height)
+			return true
+		}
+
+		entry.processedTime = substituteClientStore.Get(ProcessedTimeKey(height))
+		if entry.processedTime == nil {
+			err = sdkerrors.Wrapf(ErrProcessedTimeNotFound, "processed time not found for substitute consensus state at height %s", height)
+			return true
+		}
+
+		consensusStates = append(consensusStates, entry)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(consensusStates) == 0 {
+		return nil, sdkerrors.Wrap(clienttypes.ErrInvalidSubstitute, "substitute client has no consensus states")
+	}
+
+	// copy consensus states and processed metadata from substitute to subject
+	for _, entry := range consensusStates {
+		setConsensusState(subjectClientStore, cdc, entry.consensusState, entry.height)
+		subjectClientStore.Set(ProcessedHeightKey(entry.height), entry.processedHeight)
+		subjectClientStore.Set(ProcessedTimeKey(entry.height), entry.processedTime)
+		SetIterationKey(subjectClientStore, entry.height)
+	}
+
+	// unfreeze the client
+	cs.FrozenHeight = 0
+
+	cs.MmrRootHash = substituteClientState.MmrRootHash
+	cs.LatestBeefyHeight = substituteClientState.LatestBeefyHeight
+	cs.LatestParaHeight = substituteClientState.LatestParaHeight
+	cs.Authority = substituteClientState.Authority
+	cs.NextAuthoritySet = substituteClientState.NextAuthoritySet
+	cs.AdditionalParachains = substituteClientState.AdditionalParachains
+	cs.CommitmentPrefix = substituteClientState.CommitmentPrefix
+	cs.TimestampPalletIndex = substituteClientState.TimestampPalletIndex
+
+	// no validation is necessary since the substitute is verified to be Active
+	// in 02-client.
+
+	return cs, nil
+}
+
+// IsMatchingClientState returns true if the subject and substitute client states
+// track the same parachain on the same relay chain, their consensus states are
+// proven against the same kind of root, and their authority sets are committed for
+// the same signature scheme.
+func IsMatchingClientState(subject, substitute ClientState) bool {
+	return subject.RelayChain == substitute.RelayChain &&
+		subject.ParaId == substitute.ParaId &&
+		subject.BeefyActivationBlock == substitute.BeefyActivationBlock &&
+		subject.RootSource == substitute.RootSource &&
+		subject.SignatureType == substitute.SignatureType
+}
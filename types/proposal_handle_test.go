@@ -0,0 +1,99 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
+)
+
+func TestCheckSubstituteAndUpdateState(t *testing.T) {
+	ctx, cdc, subjectClientStore := newTestClientStore(t)
+	_, _, substituteClientStore := newTestClientStore(t)
+
+	subject := &beefytypes.ClientState{
+		LatestBeefyHeight: 10,
+		ParaId:            PARA_ID,
+		FrozenHeight:      beefytypes.FrozenHeight.GetRevisionHeight(),
+		Authority:         &beefytypes.BeefyAuthoritySet{Id: 1, Len: 5},
+		NextAuthoritySet:  &beefytypes.BeefyAuthoritySet{Id: 2, Len: 5},
+	}
+	substitute := &beefytypes.ClientState{
+		MmrRootHash:          []byte("mmr root"),
+		LatestBeefyHeight:    20,
+		LatestParaHeight:     11,
+		ParaId:               PARA_ID,
+		AdditionalParachains: newTestParachains(PARA_ID + 1),
+		CommitmentPrefix:     []byte("ibc/"),
+		Authority:            &beefytypes.BeefyAuthoritySet{Id: 3, Len: 7},
+		NextAuthoritySet:     &beefytypes.BeefyAuthoritySet{Id: 4, Len: 7},
+	}
+
+	// the substitute has no consensus states yet
+	_, err := subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectClientStore, substituteClientStore, substitute)
+	require.ErrorIs(t, err, clienttypes.ErrInvalidSubstitute)
+
+	stateRoot := bytes32([]byte("state root"))
+	heights := substitute.UpdateState(ctx, cdc, substituteClientStore, &beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+			ParachainHeaders: []*beefytypes.ParachainHeader{
+				newTestParachainHeader(t, 10, stateRoot, []byte("ibc root 10")),
+				newTestParachainHeader(t, 11, stateRoot, []byte("ibc root 11")),
+			},
+		},
+	})
+	require.Len(t, heights, 2)
+
+	mismatched := *substitute
+	mismatched.ParaId = PARA_ID + 1
+	_, err = subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectClientStore, substituteClientStore, &mismatched)
+	require.ErrorIs(t, err, clienttypes.ErrInvalidSubstitute)
+
+	// the consensus states of the substitute must be proven against the same kind of root
+	mismatched = *substitute
+	mismatched.RootSource = beefytypes.RootSource_IBC_DIGEST
+	_, err = subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectClientStore, substituteClientStore, &mismatched)
+	require.ErrorIs(t, err, clienttypes.ErrInvalidSubstitute)
+
+	// the authority sets of the substitute must be committed for the signature scheme of the subject
+	mismatched = *substitute
+	mismatched.SignatureType = beefytypes.SignatureType_BLS12_381
+	_, err = subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectClientStore, substituteClientStore, &mismatched)
+	require.ErrorIs(t, err, clienttypes.ErrInvalidSubstitute)
+
+	updated, err := subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectClientStore, substituteClientStore, substitute)
+	require.NoError(t, err)
+
+	updatedClientState, ok := updated.(*beefytypes.ClientState)
+	require.True(t, ok)
+	require.Zero(t, updatedClientState.FrozenHeight)
+	require.Equal(t, substitute.MmrRootHash, updatedClientState.MmrRootHash)
+	require.Equal(t, substitute.LatestBeefyHeight, updatedClientState.LatestBeefyHeight)
+	require.Equal(t, substitute.LatestParaHeight, updatedClientState.LatestParaHeight)
+	require.Equal(t, substitute.Authority, updatedClientState.Authority)
+	require.Equal(t, substitute.NextAuthoritySet, updatedClientState.NextAuthoritySet)
+	require.Equal(t, substitute.AdditionalParachains, updatedClientState.AdditionalParachains)
+	require.Equal(t, substitute.CommitmentPrefix, updatedClientState.CommitmentPrefix)
+
+	for _, height := range heights {
+		expected, err := beefytypes.GetConsensusState(substituteClientStore, cdc, height)
+		require.NoError(t, err)
+
+		actual, err := beefytypes.GetConsensusState(subjectClientStore, cdc, height)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		processedTime := subjectClientStore.Get(beefytypes.ProcessedTimeKey(height))
+		require.NotNil(t, processedTime)
+		require.Equal(t, substituteClientStore.Get(beefytypes.ProcessedTimeKey(height)), processedTime)
+
+		processedHeight := subjectClientStore.Get(beefytypes.ProcessedHeightKey(height))
+		require.NotNil(t, processedHeight)
+		require.Equal(t, substituteClientStore.Get(beefytypes.ProcessedHeightKey(height)), processedHeight)
+
+		require.NotNil(t, subjectClientStore.Get(beefytypes.IterationKey(height)))
+	}
+}
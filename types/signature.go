@@ -0,0 +1,178 @@
+package types
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	merkletypes "github.com/ComposableFi/go-merkle-trees/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MaxSignatureRecoveryWorkers bounds the number of goroutines that verify the signatures of a signed commitment,
+// whatever the number of available cpus.
+const MaxSignatureRecoveryWorkers = 16
+
+// Validate checks that the supermajority is a fraction of at least one half and below one, so that a final
+// commitment can't be signed by a minority of the authorities and can be signed without all of them.
+func (s Supermajority) Validate() error {
+	if s.Denominator == 0 || 2*uint64(s.Numerator) < uint64(s.Denominator) || s.Numerator >= s.Denominator {
+		return fmt.Errorf("supermajority %d/%d must be in [1/2, 1)", s.Numerator, s.Denominator)
+	}
+	return nil
+}
+
+// defaultSupermajority is the supermajority of the BEEFY protocol, commitments are final once signed by more
+// than two thirds of the authority set.
+func defaultSupermajority() Supermajority {
+	return Supermajority{Numerator: 2, Denominator: 3}
+}
+
+// AuthorityIndexError is returned for a signature of a signed commitment whose authority index is out of the
+// range of the authority set, or not greater than the authority index of the previous signature. It wraps
+// ErrInvalidAuthorityIndex.
+type AuthorityIndexError struct {
+	// Signature is the position of the signature in the signed commitment
+	Signature int
+	// AuthorityIndex is the authority index of the signature
+	AuthorityIndex uint32
+	// Reason describes why the authority index is invalid
+	Reason string
+}
+
+// Error implements the error interface.
+func (e AuthorityIndexError) Error() string {
+	return fmt.Sprintf("%s: signature %d has authority index %d, %s", ErrInvalidAuthorityIndex, e.Signature, e.AuthorityIndex, e.Reason)
+}
+
+// Unwrap returns ErrInvalidAuthorityIndex, so that the error can be matched with errors.Is.
+func (e AuthorityIndexError) Unwrap() error {
+	return ErrInvalidAuthorityIndex
+}
+
+// validateAuthorityIndices checks that the authority indices of the signatures are strictly increasing and within
+// an authority set of the given length, so that every signature is from a distinct authority of the set.
+func validateAuthorityIndices(signatures []*CommitmentSignature, authoritySetLen uint32) error {
+	for i, signature := range signatures {
+		if signature == nil {
+			return sdkerrors.Wrapf(ErrInvalidCommitmentSignature, "signature %d cannot be empty", i)
+		}
+
+		if signature.AuthorityIndex >= authoritySetLen {
+			return AuthorityIndexError{
+				Signature:      i,
+				AuthorityIndex: signature.AuthorityIndex,
+				Reason:         fmt.Sprintf("which is out of range for an authority set of %d authorities", authoritySetLen),
+			}
+		}
+
+		if i > 0 && signature.AuthorityIndex <= signatures[i-1].AuthorityIndex {
+			return AuthorityIndexError{
+				Signature:      i,
+				AuthorityIndex: signature.AuthorityIndex,
+				Reason:         fmt.Sprintf("which is not greater than the authority index %d of the previous signature", signatures[i-1].AuthorityIndex),
+			}
+		}
+	}
+
+	return nil
+}
+
+// recoverAuthorityLeaf recovers the public key that produced the signature of the commitment hash, and returns
+// the leaf of its ethereum address in the authority merkle tree.
+func recoverAuthorityLeaf(commitmentHash []byte, signature *CommitmentSignature) (merkletypes.Leaf, error) {
+	if signature == nil {
+		return merkletypes.Leaf{}, sdkerrors.Wrap(ErrInvalidCommitmentSignature, "signature cannot be empty")
+	}
+
+	// recover uncompressed public key from signature
+	pubkey, err := crypto.SigToPub(commitmentHash, signature.Signature)
+	if err != nil {
+		return merkletypes.Leaf{}, sdkerrors.Wrap(ErrInvalidCommitmentSignature, err.Error())
+	}
+
+	// convert public key to ethereum address.
+	address := crypto.PubkeyToAddress(*pubkey)
+	return merkletypes.Leaf{
+		Hash:  crypto.Keccak256(address[:]),
+		Index: uint64(signature.AuthorityIndex),
+	}, nil
+}
+
+// recoverAuthorityLeaves recovers the authority leaves of the signatures, in the order of the signatures.
+func recoverAuthorityLeaves(commitmentHash []byte, signatures []*CommitmentSignature) ([]merkletypes.Leaf, error) {
+	return recoverAuthorityLeavesWith(forEachSignature, commitmentHash, signatures)
+}
+
+// recoverAuthorityLeavesWith recovers the authority leaves of the signatures, scheduling the recoveries with forEach.
+func recoverAuthorityLeavesWith(
+	forEach func(n int, verify func(i int) error) error, commitmentHash []byte, signatures []*CommitmentSignature,
+) ([]merkletypes.Leaf, error) {
+	authorityLeaves := make([]merkletypes.Leaf, len(signatures))
+	err := forEach(len(signatures), func(i int) (err error) {
+		authorityLeaves[i], err = recoverAuthorityLeaf(commitmentHash, signatures[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return authorityLeaves, nil
+}
+
+// forEachSignatureSequential calls verify for the signatures one after the other, and returns the error of the
+// first signature that fails.
+func forEachSignatureSequential(n int, verify func(i int) error) error {
+	for i := 0; i < n; i++ {
+		if err := verify(i); err != nil {
+			return sdkerrors.Wrapf(err, "signature %d", i)
+		}
+	}
+
+	return nil
+}
+
+// forEachSignatureParallel calls verify for the signatures on a bounded pool of workers. When several signatures
+// fail the error of the first one is returned, so that the result is the same as forEachSignatureSequential on
+// every node. verify must only write to the state of the signature it is called for.
+func forEachSignatureParallel(n int, verify func(i int) error) error {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > MaxSignatureRecoveryWorkers {
+		workers = MaxSignatureRecoveryWorkers
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		return forEachSignatureSequential(n, verify)
+	}
+
+	errs := make([]error, n)
+
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = verify(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return sdkerrors.Wrapf(err, "signature %d", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// kusama has 667 beefy authorities, a supermajority of which sign every commitment
+func BenchmarkRecoverAuthorityLeaves(b *testing.B) {
+	commitmentHash := crypto.Keccak256([]byte("commitment"))
+
+	signatures := make([]*CommitmentSignature, 445)
+	for i := range signatures {
+		key, err := crypto.GenerateKey()
+		require.NoError(b, err)
+
+		signature, err := crypto.Sign(commitmentHash, key)
+		require.NoError(b, err)
+		signatures[i] = &CommitmentSignature{Signature: signature, AuthorityIndex: uint32(i)}
+	}
+
+	for _, bc := range []struct {
+		name    string
+		forEach func(n int, verify func(i int) error) error
+	}{
+		{"sequential", forEachSignatureSequential},
+		{"parallel", forEachSignatureParallel},
+	} {
+		b.Run(fmt.Sprintf("%s/%d", bc.name, len(signatures)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := recoverAuthorityLeavesWith(bc.forEach, commitmentHash, signatures); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	merkletypes "github.com/ComposableFi/go-merkle-trees/types"
+	"github.com/cloudflare/circl/ecc/bls12381"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+// BLSDomainSeparationTag is the domain separation tag with which the TinyBLS engines of w3f-bls hash messages to
+// G1, a single byte 1.
+const BLSDomainSeparationTag = "\x01"
+
+// blsMessageSize is the size of the digest of a w3f-bls message, which is the input of the hash to G1.
+const blsMessageSize = 32
+
+// BLS12381Scheme is the TinyBLS381 signature scheme of the bls381 keys of substrate, which come from w3f-bls:
+// BLS12-381 with signatures in G1 and public keys in G2. Messages are hashed to G1 with the
+// BLS12381G1_XMD:SHA-256_SSWU_RO_ suite of RFC 9380 and BLSDomainSeparationTag, see HashToG1. The authorities
+// that signed the commitment are listed with their public key in the signatures, whose own signature bytes are
+// unused, and their signatures are aggregated in the AggregateSignature of the signed commitment. Authorities are
+// committed to by the keccak256 hash of their public key, the relay chain is expected to check a proof of possession
+// of every key before it joins an authority set, which rules out rogue key attacks on the aggregate.
+//
+// Points are encoded compressed in the format of the IETF BLS signature draft (zcash): 48 bytes for G1 signatures
+// and 96 bytes for G2 public keys. The arkworks encoding of substrate keys and signatures is converted by the relayer.
+type BLS12381Scheme struct{}
+
+var _ SignatureScheme = BLS12381Scheme{}
+
+// AuthorityLeafHash returns the keccak256 hash of the compressed G2 public key.
+func (BLS12381Scheme) AuthorityLeafHash(publicKey []byte) ([]byte, error) {
+	if _, err := decodeBLSPublicKey(publicKey); err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidAuthoritySet, err.Error())
+	}
+	return crypto.Keccak256(publicKey), nil
+}
+
+// VerifySignatures checks that the aggregate signature of the signed commitment is the aggregate of signatures of
+// the commitment hash by all the public keys of the signatures.
+func (s BLS12381Scheme) VerifySignatures(commitmentHash []byte, signedCommitment *SignedCommitment) ([]merkletypes.Leaf, error) {
+	signatures := signedCommitment.Signatures
+	if len(signatures) == 0 {
+		return nil, sdkerrors.Wrap(ErrInvalidCommitmentSignature, "signed commitment has no signers")
+	}
+
+	// checking that the public keys are in the prime order subgroup is the expensive part
+	authorityLeaves := make([]merkletypes.Leaf, len(signatures))
+	publicKeys := make([]*bls12381.G2, len(signatures))
+	err := forEachSignature(len(signatures), func(i int) error {
+		signature := signatures[i]
+		if signature == nil {
+			return sdkerrors.Wrap(ErrInvalidCommitmentSignature, "signature cannot be empty")
+		}
+
+		publicKey, err := decodeBLSPublicKey(signature.PublicKey)
+		if err != nil {
+			return sdkerrors.Wrap(ErrInvalidCommitmentSignature, err.Error())
+		}
+
+		publicKeys[i] = publicKey
+		authorityLeaves[i] = merkletypes.Leaf{
+			Hash:  crypto.Keccak256(signature.PublicKey),
+			Index: uint64(signature.AuthorityIndex),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	aggregatePublicKey := new(bls12381.G2)
+	aggregatePublicKey.SetIdentity()
+	for _, publicKey := range publicKeys {
+		aggregatePublicKey.Add(aggregatePublicKey, publicKey)
+	}
+
+	if len(signedCommitment.AggregateSignature) != bls12381.G1SizeCompressed {
+		return nil, sdkerrors.Wrapf(ErrInvalidCommitmentSignature, "aggregate signature must be %d bytes, got %d",
+			bls12381.G1SizeCompressed, len(signedCommitment.AggregateSignature))
+	}
+	aggregateSignature := new(bls12381.G1)
+	if err := aggregateSignature.SetBytes(signedCommitment.AggregateSignature); err != nil {
+		return nil, sdkerrors.Wrapf(ErrInvalidCommitmentSignature, "invalid aggregate signature: %s", err)
+	}
+	if aggregateSignature.IsIdentity() {
+		return nil, sdkerrors.Wrap(ErrInvalidCommitmentSignature, "aggregate signature cannot be the identity")
+	}
+
+	message := s.HashToG1(commitmentHash)
+
+	// e(aggregate signature, g2) == e(H(m), aggregate public key)
+	pairing := bls12381.ProdPairFrac(
+		[]*bls12381.G1{aggregateSignature, message},
+		[]*bls12381.G2{bls12381.G2Generator(), aggregatePublicKey},
+		[]int{1, -1},
+	)
+	if !pairing.IsIdentity() {
+		return nil, sdkerrors.Wrap(ErrInvalidCommitmentSignature, "aggregate signature does not verify against the public keys of the signers")
+	}
+
+	return authorityLeaves, nil
+}
+
+// HashToG1 hashes a message to the point of G1 that is signed by the authorities. Like w3f-bls for a message
+// without context, the message is first reduced to its SHAKE128 digest, prefixed with its length, which is then
+// hashed to G1 with BLSDomainSeparationTag.
+func (BLS12381Scheme) HashToG1(message []byte) *bls12381.G1 {
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(message)))
+
+	h := sha3.NewShake128()
+	h.Write(length[:])
+	h.Write(message)
+	digest := make([]byte, blsMessageSize)
+	// reading from a shake hash never fails
+	_, _ = h.Read(digest)
+
+	point := new(bls12381.G1)
+	point.Hash(digest, []byte(BLSDomainSeparationTag))
+	return point
+}
+
+// decodeBLSPublicKey decodes a compressed G2 public key, which must not be the identity. Decoding checks that
+// the key is in the prime order subgroup.
+func decodeBLSPublicKey(publicKey []byte) (*bls12381.G2, error) {
+	if len(publicKey) != bls12381.G2SizeCompressed {
+		return nil, fmt.Errorf("BLS public key must be %d bytes, got %d", bls12381.G2SizeCompressed, len(publicKey))
+	}
+
+	point := new(bls12381.G2)
+	if err := point.SetBytes(publicKey); err != nil {
+		return nil, fmt.Errorf("invalid BLS public key: %w", err)
+	}
+	if point.IsIdentity() {
+		return nil, fmt.Errorf("BLS public key cannot be the identity")
+	}
+	return point, nil
+}
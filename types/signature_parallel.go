@@ -0,0 +1,7 @@
+//go:build !beefy_sequential
+
+package types
+
+// forEachSignature verifies the signatures of a signed commitment on a bounded pool of workers. Build with
+// the beefy_sequential tag to verify them one after the other instead.
+var forEachSignature = forEachSignatureParallel
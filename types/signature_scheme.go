@@ -0,0 +1,63 @@
+package types
+
+import (
+	merkletypes "github.com/ComposableFi/go-merkle-trees/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignatureScheme verifies the signatures of signed commitments for one SignatureType. The authority root of an
+// authority set is the root of a merkle tree whose leaves are the AuthorityLeafHash of every authority in the set,
+// so every scheme defines the commitment to the authority set that matches its keys.
+type SignatureScheme interface {
+	// AuthorityLeafHash returns the hash of the leaf of the authority with the given public key in the merkle tree
+	// of its authority set.
+	AuthorityLeafHash(publicKey []byte) ([]byte, error)
+	// VerifySignatures verifies the signatures of the signed commitment over the commitment hash, and returns the
+	// leaves of the signers in the merkle tree of their authority set, in the order of the signatures.
+	VerifySignatures(commitmentHash []byte, signedCommitment *SignedCommitment) ([]merkletypes.Leaf, error)
+}
+
+// SignatureSchemes are the signature schemes that a client state can select with its SignatureType. A scheme
+// can be registered by the app before any client is created, but must be the same on every node.
+var SignatureSchemes = map[SignatureType]SignatureScheme{
+	SignatureType_ECDSA:     ECDSAScheme{},
+	SignatureType_BLS12_381: BLS12381Scheme{},
+}
+
+// signatureScheme returns the signature scheme selected by the client state.
+func (cs ClientState) signatureScheme() (SignatureScheme, error) {
+	scheme, ok := SignatureSchemes[cs.SignatureType]
+	if !ok {
+		return nil, sdkerrors.Wrapf(ErrInvalidSignatureScheme, "no signature scheme registered for signature type %s", cs.SignatureType)
+	}
+	return scheme, nil
+}
+
+// ECDSAScheme is the secp256k1 ECDSA signature scheme of BEEFY. Every authority signs the commitment, and the
+// signer is recovered from the signature, so the public keys of the signatures are unused. Authorities are
+// committed to by the keccak256 hash of their ethereum address.
+type ECDSAScheme struct{}
+
+var _ SignatureScheme = ECDSAScheme{}
+
+// AuthorityLeafHash returns the keccak256 hash of the ethereum address of the compressed or uncompressed
+// secp256k1 public key.
+func (ECDSAScheme) AuthorityLeafHash(publicKey []byte) ([]byte, error) {
+	var err error
+	pubkey, decompressErr := crypto.DecompressPubkey(publicKey)
+	if decompressErr != nil {
+		pubkey, err = crypto.UnmarshalPubkey(publicKey)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(ErrInvalidAuthoritySet, "invalid secp256k1 public key: %s", err)
+		}
+	}
+
+	address := crypto.PubkeyToAddress(*pubkey)
+	return crypto.Keccak256(address[:]), nil
+}
+
+// VerifySignatures recovers the signer of every signature.
+func (ECDSAScheme) VerifySignatures(commitmentHash []byte, signedCommitment *SignedCommitment) ([]merkletypes.Leaf, error) {
+	return recoverAuthorityLeaves(commitmentHash, signedCommitment.Signatures)
+}
@@ -0,0 +1,7 @@
+//go:build beefy_sequential
+
+package types
+
+// forEachSignature verifies the signatures of a signed commitment one after the other, for builds that
+// don't want goroutines in consensus critical code.
+var forEachSignature = forEachSignatureSequential
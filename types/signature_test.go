@@ -0,0 +1,256 @@
+package types_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/bls12381"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
+)
+
+func TestVerifyClientMessageInvalidSignatures(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	authorities := newTestAuthorities(t, 40)
+
+	signers := make([]uint32, len(authorities))
+	for i := range signers {
+		signers[i] = uint32(i)
+	}
+
+	clientState := &beefytypes.ClientState{
+		LatestBeefyHeight: 100,
+		ParaId:            PARA_ID,
+		Authority:         authorities.authoritySet(1),
+		NextAuthoritySet:  authorities.authoritySet(2),
+	}
+	commitment := &beefytypes.Commitment{
+		Payload:        []*beefytypes.PayloadItem{{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: make([]byte, 32)}},
+		BlockNumer:     90,
+		ValidatorSetId: 1,
+	}
+
+	// the first invalid signature is reported, however the recovery is scheduled
+	for i := 0; i < 10; i++ {
+		header := authorities.signedHeader(t, commitment, signers)
+		header.ClientState.SignedCommitment.Signatures[31].Signature = []byte("invalid signature")
+		header.ClientState.SignedCommitment.Signatures[7].Signature = []byte("invalid signature")
+
+		err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+		require.ErrorIs(t, err, beefytypes.ErrInvalidCommitmentSignature)
+		require.Contains(t, err.Error(), "signature 7")
+	}
+
+	// signatures of other keys recover to leaves that are not in the authority set
+	header := authorities.signedHeader(t, commitment, signers)
+	otherHeader := newTestAuthorities(t, 40).signedHeader(t, commitment, signers)
+	header.ClientState.SignedCommitment.Signatures[5] = otherHeader.ClientState.SignedCommitment.Signatures[5]
+
+	err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+	require.ErrorIs(t, err, beefytypes.ErrAuthoritySetUnknown)
+}
+
+func TestVerifyClientMessageAuthorityIndices(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	authorities := newTestAuthorities(t, 4)
+
+	clientState := &beefytypes.ClientState{
+		LatestBeefyHeight: 100,
+		ParaId:            PARA_ID,
+		Authority:         authorities.authoritySet(1),
+		NextAuthoritySet:  authorities.authoritySet(2),
+	}
+	commitment := &beefytypes.Commitment{
+		Payload:        []*beefytypes.PayloadItem{{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: make([]byte, 32)}},
+		BlockNumer:     90,
+		ValidatorSetId: 1,
+	}
+
+	testCases := []struct {
+		name         string
+		malleate     func(signatures []*beefytypes.CommitmentSignature) []*beefytypes.CommitmentSignature
+		expSignature int
+	}{
+		{"repeated signature inflating the count", func(signatures []*beefytypes.CommitmentSignature) []*beefytypes.CommitmentSignature {
+			return []*beefytypes.CommitmentSignature{signatures[0], signatures[1], signatures[1]}
+		}, 2},
+		{"decreasing authority indices", func(signatures []*beefytypes.CommitmentSignature) []*beefytypes.CommitmentSignature {
+			signatures[1], signatures[2] = signatures[2], signatures[1]
+			return signatures
+		}, 2},
+		{"authority index out of range", func(signatures []*beefytypes.CommitmentSignature) []*beefytypes.CommitmentSignature {
+			signatures[3].AuthorityIndex = 4
+			return signatures
+		}, 3},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			header := authorities.signedHeader(t, commitment, []uint32{0, 1, 2, 3})
+			signedCommitment := header.ClientState.SignedCommitment
+			signedCommitment.Signatures = tc.malleate(signedCommitment.Signatures)
+
+			err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+			require.ErrorIs(t, err, beefytypes.ErrInvalidAuthorityIndex)
+
+			var indexErr beefytypes.AuthorityIndexError
+			require.True(t, errors.As(err, &indexErr))
+			require.Equal(t, tc.expSignature, indexErr.Signature)
+			require.Equal(t, signedCommitment.Signatures[tc.expSignature].AuthorityIndex, indexErr.AuthorityIndex)
+		})
+	}
+
+	// a supermajority of distinct authorities is still required
+	header := authorities.signedHeader(t, commitment, []uint32{0, 2})
+	err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+	require.ErrorIs(t, err, beefytypes.ErrCommitmentNotFinal)
+}
+
+func TestSignatureSchemes(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	ecdsaAuthorities := newTestAuthorities(t, 4)
+	blsAuthorities := newTestBLSAuthorities(t, 4)
+
+	newClientState := func(signatureType beefytypes.SignatureType, authorities testSigners) *beefytypes.ClientState {
+		return &beefytypes.ClientState{
+			LatestBeefyHeight: 100,
+			ParaId:            PARA_ID,
+			Authority:         authorities.authoritySet(1),
+			NextAuthoritySet:  authorities.authoritySet(2),
+			SignatureType:     signatureType,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		clientState *beefytypes.ClientState
+		header      func() *beefytypes.Header
+		expErr      error
+	}{
+		{"ecdsa signatures", newClientState(beefytypes.SignatureType_ECDSA, ecdsaAuthorities), func() *beefytypes.Header {
+			return newTestRotationHeader(t, ecdsaAuthorities, 1, []uint32{0, 1, 3}, 110, ecdsaAuthorities.authoritySet(2))
+		}, nil},
+		{"bls aggregate signature", newClientState(beefytypes.SignatureType_BLS12_381, blsAuthorities), func() *beefytypes.Header {
+			return newTestRotationHeader(t, blsAuthorities, 1, []uint32{0, 1, 3}, 110, blsAuthorities.authoritySet(2))
+		}, nil},
+		{"bls aggregate signature missing a signer", newClientState(beefytypes.SignatureType_BLS12_381, blsAuthorities), func() *beefytypes.Header {
+			header := newTestRotationHeader(t, blsAuthorities, 1, []uint32{0, 1, 3}, 110, blsAuthorities.authoritySet(2))
+			partial := newTestRotationHeader(t, blsAuthorities, 1, []uint32{0, 1}, 110, blsAuthorities.authoritySet(2))
+			header.ClientState.SignedCommitment.AggregateSignature = partial.ClientState.SignedCommitment.AggregateSignature
+			return header
+		}, beefytypes.ErrInvalidCommitmentSignature},
+		{"bls aggregate signature of another commitment", newClientState(beefytypes.SignatureType_BLS12_381, blsAuthorities), func() *beefytypes.Header {
+			header := newTestRotationHeader(t, blsAuthorities, 1, []uint32{0, 1, 3}, 110, blsAuthorities.authoritySet(2))
+			other := newTestRotationHeader(t, blsAuthorities, 1, []uint32{0, 1, 3}, 111, blsAuthorities.authoritySet(2))
+			header.ClientState.SignedCommitment.AggregateSignature = other.ClientState.SignedCommitment.AggregateSignature
+			return header
+		}, beefytypes.ErrInvalidCommitmentSignature},
+		{"bls signers outside of the authority set", newClientState(beefytypes.SignatureType_BLS12_381, blsAuthorities), func() *beefytypes.Header {
+			return newTestRotationHeader(t, newTestBLSAuthorities(t, 4), 1, []uint32{0, 1, 3}, 110, blsAuthorities.authoritySet(2))
+		}, beefytypes.ErrAuthoritySetUnknown},
+		{"bls signatures for an ecdsa client", newClientState(beefytypes.SignatureType_ECDSA, blsAuthorities), func() *beefytypes.Header {
+			return newTestRotationHeader(t, blsAuthorities, 1, []uint32{0, 1, 3}, 110, blsAuthorities.authoritySet(2))
+		}, beefytypes.ErrInvalidCommitmentSignature},
+		{"ecdsa signatures for a bls client", newClientState(beefytypes.SignatureType_BLS12_381, ecdsaAuthorities), func() *beefytypes.Header {
+			return newTestRotationHeader(t, ecdsaAuthorities, 1, []uint32{0, 1, 3}, 110, ecdsaAuthorities.authoritySet(2))
+		}, beefytypes.ErrInvalidCommitmentSignature},
+		{"unknown signature type", newClientState(2, ecdsaAuthorities), func() *beefytypes.Header {
+			return newTestRotationHeader(t, ecdsaAuthorities, 1, []uint32{0, 1, 3}, 110, ecdsaAuthorities.authoritySet(2))
+		}, beefytypes.ErrInvalidSignatureScheme},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.clientState.VerifyClientMessage(ctx, cdc, clientStore, tc.header())
+			if tc.expErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, uint32(110), tc.clientState.LatestBeefyHeight)
+			} else {
+				require.ErrorIs(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestAuthorityLeafHash(t *testing.T) {
+	ecdsaAuthorities := newTestAuthorities(t, 1)
+	address := crypto.PubkeyToAddress(ecdsaAuthorities[0].PublicKey)
+
+	for _, publicKey := range [][]byte{
+		crypto.FromECDSAPub(&ecdsaAuthorities[0].PublicKey),
+		crypto.CompressPubkey(&ecdsaAuthorities[0].PublicKey),
+	} {
+		leafHash, err := beefytypes.ECDSAScheme{}.AuthorityLeafHash(publicKey)
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256(address[:]), leafHash)
+	}
+
+	blsAuthorities := newTestBLSAuthorities(t, 1)
+	leafHash, err := beefytypes.BLS12381Scheme{}.AuthorityLeafHash(blsAuthorities[0].publicKey)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256(blsAuthorities[0].publicKey), leafHash)
+
+	// the keys of one scheme are not keys of the other
+	_, err = beefytypes.ECDSAScheme{}.AuthorityLeafHash(blsAuthorities[0].publicKey)
+	require.ErrorIs(t, err, beefytypes.ErrInvalidAuthoritySet)
+	_, err = beefytypes.BLS12381Scheme{}.AuthorityLeafHash(crypto.FromECDSAPub(&ecdsaAuthorities[0].PublicKey))
+	require.ErrorIs(t, err, beefytypes.ErrInvalidAuthoritySet)
+	identity := new(bls12381.G2)
+	identity.SetIdentity()
+	_, err = beefytypes.BLS12381Scheme{}.AuthorityLeafHash(identity.BytesCompressed())
+	require.ErrorIs(t, err, beefytypes.ErrInvalidAuthoritySet, "the identity is not a public key")
+	uncompressed := new(bls12381.G2)
+	require.NoError(t, uncompressed.SetBytes(blsAuthorities[0].publicKey))
+	_, err = beefytypes.BLS12381Scheme{}.AuthorityLeafHash(uncompressed.Bytes())
+	require.ErrorIs(t, err, beefytypes.ErrInvalidAuthoritySet, "public keys are compressed")
+}
+
+// blsTestKey is the BLS12-381 key of an authority, whose leaf is the hash of its public key. Its signatures
+// are aggregated into the aggregate signature of the signed commitment.
+type blsTestKey struct {
+	secretKey *bls12381.Scalar
+	publicKey []byte
+}
+
+// newTestBLSAuthorities returns an authority set of n BLS12-381 keys.
+func newTestBLSAuthorities(t *testing.T, n int) testAuthorities[blsTestKey] {
+	return generateTestAuthorities(t, n, newBLSTestKey)
+}
+
+func newBLSTestKey(t *testing.T) blsTestKey {
+	secretKey := new(bls12381.Scalar)
+	require.NoError(t, secretKey.Random(rand.Reader))
+
+	publicKey := new(bls12381.G2)
+	publicKey.ScalarMult(secretKey, bls12381.G2Generator())
+	return blsTestKey{secretKey: secretKey, publicKey: publicKey.BytesCompressed()}
+}
+
+func (k blsTestKey) leaf() []byte {
+	return crypto.Keccak256(k.publicKey)
+}
+
+func (k blsTestKey) sign(t *testing.T, commitmentHash []byte, index uint32, signedCommitment *beefytypes.SignedCommitment) {
+	signature := new(bls12381.G1)
+	signature.ScalarMult(k.secretKey, beefytypes.BLS12381Scheme{}.HashToG1(commitmentHash))
+
+	aggregateSignature := new(bls12381.G1)
+	aggregateSignature.SetIdentity()
+	if signedCommitment.AggregateSignature != nil {
+		require.NoError(t, aggregateSignature.SetBytes(signedCommitment.AggregateSignature))
+	}
+	aggregateSignature.Add(aggregateSignature, signature)
+	signedCommitment.AggregateSignature = aggregateSignature.BytesCompressed()
+
+	signedCommitment.Signatures = append(signedCommitment.Signatures, &beefytypes.CommitmentSignature{
+		AuthorityIndex: index,
+		PublicKey:      k.publicKey,
+	})
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
@@ -15,6 +17,9 @@ import (
 
 const KeyIterateConsensusStatePrefix = "iterateConsensusStates"
 
+// MaxPrunedConsensusStatesPerUpdate is the maximum number of consensus states deleted by a single client update.
+const MaxPrunedConsensusStatesPerUpdate = 100
+
 var (
 	// KeyProcessedTime is appended to consensus state key to store the processed time
 	KeyProcessedTime = []byte("/processedTime")
@@ -56,12 +61,14 @@ func GetConsensusState(store sdk.KVStore, cdc codec.BinaryCodec, height exported
 	return consensusState, nil
 }
 
-// GetPreviousConsensusState returns the highest consensus state that is lower than the given height.
+// GetPreviousConsensusState returns the highest consensus state that is lower than the given height,
+// with the same revision number. Each parachain uses its para id as the revision number.
 // The Iterator returns a storetypes.Iterator which iterates from the end (exclusive) to start (inclusive).
 // Thus to get previous consensus state we call iterator.Value() immediately.
 func GetPreviousConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, height exported.Height) (*ConsensusState, bool) {
 	iterateStore := prefix.NewStore(clientStore, []byte(KeyIterateConsensusStatePrefix))
-	iterator := iterateStore.ReverseIterator(nil, bigEndianHeightBytes(height))
+	revisionStart := bigEndianHeightBytes(clienttypes.NewHeight(height.GetRevisionNumber(), 0))
+	iterator := iterateStore.ReverseIterator(revisionStart, bigEndianHeightBytes(height))
 	defer iterator.Close()
 
 	if !iterator.Valid() {
@@ -73,13 +80,14 @@ func GetPreviousConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, h
 	return getTmConsensusState(clientStore, cdc, csKey)
 }
 
-// GetNextConsensusState returns the lowest consensus state that is larger than the given height.
+// GetNextConsensusState returns the lowest consensus state that is larger than the given height,
+// with the same revision number. Each parachain uses its para id as the revision number.
 // The Iterator returns a storetypes.Iterator which iterates from start (inclusive) to end (exclusive).
 // If the starting height exists in store, we need to call iterator.Next() to get the next consenus state.
 // Otherwise, the iterator is already at the next consensus state so we can call iterator.Value() immediately.
 func GetNextConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, height exported.Height) (*ConsensusState, bool) {
 	iterateStore := prefix.NewStore(clientStore, []byte(KeyIterateConsensusStatePrefix))
-	iterator := iterateStore.Iterator(bigEndianHeightBytes(height), nil)
+	iterator := iterateStore.Iterator(bigEndianHeightBytes(height), revisionEnd(height))
 	defer iterator.Close()
 	if !iterator.Valid() {
 		return nil, false
@@ -99,6 +107,14 @@ func GetNextConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, heigh
 	return getTmConsensusState(clientStore, cdc, csKey)
 }
 
+// revisionEnd returns the exclusive end of the iteration keys with the revision number of the given height.
+func revisionEnd(height exported.Height) []byte {
+	if height.GetRevisionNumber() == math.MaxUint64 {
+		return nil
+	}
+	return bigEndianHeightBytes(clienttypes.NewHeight(height.GetRevisionNumber()+1, 0))
+}
+
 // Helper function for GetNextConsensusState and GetPreviousConsensusState
 func getTmConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, key []byte) (*ConsensusState, bool) {
 	bz := clientStore.Get(key)
@@ -134,6 +150,15 @@ func setConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, consensus
 	clientStore.Set(key, val)
 }
 
+// deleteConsensusState deletes the consensus state at the given height, along with its processed time,
+// processed height and iteration key.
+func deleteConsensusState(clientStore sdk.KVStore, height exported.Height) {
+	clientStore.Delete(host.ConsensusStateKey(height))
+	clientStore.Delete(ProcessedTimeKey(height))
+	clientStore.Delete(ProcessedHeightKey(height))
+	clientStore.Delete(IterationKey(height))
+}
+
 // setConsensusMetadata sets context time as processed time and set context height as processed height
 // as this is internal tendermint light client logic.
 // client state and consensus state will be set by client keeper
@@ -164,6 +189,59 @@ func SetProcessedTime(clientStore sdk.KVStore, height exported.Height, timeNs ui
 	clientStore.Set(key, val)
 }
 
+// GetProcessedTime gets the time (in nanoseconds) at which this chain received and processed a beefy header.
+// This is used to validate that a received packet has passed the time delay period.
+func GetProcessedTime(clientStore sdk.KVStore, height exported.Height) (uint64, error) {
+	key := ProcessedTimeKey(height)
+	bz := clientStore.Get(key)
+	if bz == nil {
+		return 0, sdkerrors.Wrapf(ErrProcessedTimeNotFound, "processed time not found for height %s", height)
+	}
+	return sdk.BigEndianToUint64(bz), nil
+}
+
+// IterateConsensusMetadata iterates through the processed times, processed heights and iteration keys of
+// the consensus states, and applies the callback to each of them. If the cb returns true, then iterator will
+// close and stop.
+func IterateConsensusMetadata(clientStore sdk.KVStore, cb func(key, val []byte) bool) {
+	iterator := sdk.KVStorePrefixIterator(clientStore, []byte(host.KeyConsensusStatePrefix))
+	defer iterator.Close()
+
+	// iterate over processed time and processed height
+	for ; iterator.Valid(); iterator.Next() {
+		// processed time key in prefix store has format: "consensusStates/<height>/processedTime"
+		if !isProcessedMetadataKey(iterator.Key()) {
+			// ignore all consensus state keys
+			continue
+		}
+
+		if cb(iterator.Key(), iterator.Value()) {
+			return
+		}
+	}
+
+	// iterate over iteration keys
+	iterationIterator := sdk.KVStorePrefixIterator(clientStore, []byte(KeyIterateConsensusStatePrefix))
+	defer iterationIterator.Close()
+
+	for ; iterationIterator.Valid(); iterationIterator.Next() {
+		if cb(iterationIterator.Key(), iterationIterator.Value()) {
+			return
+		}
+	}
+}
+
+// isProcessedMetadataKey returns true if the key is the processed time or processed height key of a consensus state.
+func isProcessedMetadataKey(key []byte) bool {
+	keySplit := strings.Split(string(key), "/")
+	if len(keySplit) != 3 || keySplit[0] != host.KeyConsensusStatePrefix {
+		return false
+	}
+
+	suffix := "/" + keySplit[2]
+	return suffix == string(KeyProcessedTime) || suffix == string(KeyProcessedHeight)
+}
+
 // ProcessedTimeKey returns the key under which the processed time will be stored in the client store.
 func ProcessedTimeKey(height exported.Height) []byte {
 	return append(host.ConsensusStateKey(height), KeyProcessedTime...)
@@ -183,6 +261,21 @@ func SetProcessedHeight(clientStore sdk.KVStore, consHeight, processedHeight exp
 	clientStore.Set(key, val)
 }
 
+// GetProcessedHeight gets the height at which this chain received and processed a beefy header.
+// This is used to validate that a received packet has passed the block delay period.
+func GetProcessedHeight(clientStore sdk.KVStore, height exported.Height) (exported.Height, error) {
+	key := ProcessedHeightKey(height)
+	bz := clientStore.Get(key)
+	if bz == nil {
+		return nil, sdkerrors.Wrapf(ErrProcessedHeightNotFound, "processed height not found for height %s", height)
+	}
+	processedHeight, err := clienttypes.ParseHeight(string(bz))
+	if err != nil {
+		return nil, sdkerrors.Wrapf(ErrProcessedHeightNotFound, "processed height for height %s could not be parsed: %v", height, err)
+	}
+	return processedHeight, nil
+}
+
 // SetIterationKey stores the consensus state key under a key that is more efficient for ordered iteration
 func SetIterationKey(clientStore sdk.KVStore, height exported.Height) {
 	key := IterationKey(height)
@@ -196,3 +289,42 @@ func IterationKey(height exported.Height) []byte {
 	heightBytes := bigEndianHeightBytes(height)
 	return append([]byte(KeyIterateConsensusStatePrefix), heightBytes...)
 }
+
+// GetHeightFromIterationKey takes an iteration key and returns the height that it references
+func GetHeightFromIterationKey(iterKey []byte) exported.Height {
+	bigEndianBytes := iterKey[len([]byte(KeyIterateConsensusStatePrefix)):]
+	revisionBytes := bigEndianBytes[0:8]
+	heightBytes := bigEndianBytes[8:]
+	revision := binary.BigEndian.Uint64(revisionBytes)
+	height := binary.BigEndian.Uint64(heightBytes)
+	return clienttypes.NewHeight(revision, height)
+}
+
+// IterateParachainConsensusStates iterates through the consensus states of the given parachain in ascending order.
+// It calls the provided callback on each height, until stop=true is returned.
+func IterateParachainConsensusStates(clientStore sdk.KVStore, paraId uint32, cb func(height exported.Height) (stop bool)) {
+	paraPrefix := make([]byte, 8)
+	binary.BigEndian.PutUint64(paraPrefix, uint64(paraId))
+
+	iterator := sdk.KVStorePrefixIterator(clientStore, append([]byte(KeyIterateConsensusStatePrefix), paraPrefix...))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(GetHeightFromIterationKey(iterator.Key())) {
+			return
+		}
+	}
+}
+
+// IterateConsensusStateAscending iterates through the consensus states in ascending order. It calls the provided
+// callback on each height, until stop=true is returned.
+func IterateConsensusStateAscending(clientStore sdk.KVStore, cb func(height exported.Height) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(clientStore, []byte(KeyIterateConsensusStatePrefix))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(GetHeightFromIterationKey(iterator.Key())) {
+			return
+		}
+	}
+}
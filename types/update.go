@@ -3,9 +3,8 @@ package types
 import (
 	"bytes"
 	"fmt"
-	"reflect"
+	"time"
 
-	"github.com/ChainSafe/log15"
 	"github.com/ComposableFi/go-merkle-trees/hasher"
 	"github.com/ComposableFi/go-merkle-trees/merkle"
 	"github.com/ComposableFi/go-merkle-trees/mmr"
@@ -15,7 +14,6 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
-	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
 	"github.com/cosmos/ibc-go/v5/modules/core/exported"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -28,6 +26,8 @@ func (cs *ClientState) VerifyClientMessage(
 	switch msg := clientMsg.(type) {
 	case *Header:
 		return cs.verifyHeader(ctx, clientStore, cdc, msg)
+	case *Misbehaviour:
+		return cs.verifyMisbehaviour(ctx, clientStore, cdc, msg)
 	default:
 		return clienttypes.ErrInvalidClientType
 	}
@@ -45,85 +45,26 @@ func (cs *ClientState) verifyHeader(
 	ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec,
 	beefyHeader *Header,
 ) error {
+	if beefyHeader.ClientState == nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidHeader, "header must contain a client state update")
+	}
+
 	var (
 		clientState      = beefyHeader.ClientState
 		authoritiesProof = beefyHeader.ClientState.AuthoritiesProof
 		signedCommitment = beefyHeader.ClientState.SignedCommitment
 	)
 
-	// checking signatures is expensive (667 authorities for kusama),
-	// we want to know if these sigs meet the minimum threshold before proceeding
-	// and are by a known authority set (the current one, or the next one)
-	if authoritiesThreshold(*cs.Authority) > uint32(len(signedCommitment.Signatures)) ||
-		authoritiesThreshold(*cs.NextAuthoritySet) > uint32(len(signedCommitment.Signatures)) {
-		return ErrCommitmentNotFinal
-	}
-
-	if signedCommitment.Commitment.ValidatorSetId != cs.Authority.Id &&
-		signedCommitment.Commitment.ValidatorSetId != cs.NextAuthoritySet.Id {
-		return ErrAuthoritySetUnknown
-	}
-
-	// beefy authorities are signing the hash of the scale-encoded Commitment
-	commitmentBytes, err := rpcclienttypes.Encode(&signedCommitment.Commitment)
+	updatedAuthority, err := cs.verifySignedCommitment(signedCommitment, authoritiesProof)
 	if err != nil {
-		return sdkerrors.Wrap(err, ErrInvalidCommitment.Error())
-	}
-
-	// take keccak hash of the commitment scale-encoded
-	commitmentHash := crypto.Keccak256(commitmentBytes)
-
-	// array of leaves in the authority merkle root.
-	var authorityLeaves []merkletypes.Leaf
-
-	for i := 0; i < len(signedCommitment.Signatures); i++ {
-		signature := signedCommitment.Signatures[i]
-		// recover uncompressed public key from signature
-		pubkey, err := crypto.SigToPub(commitmentHash, signature.Signature)
-		if err != nil {
-			return sdkerrors.Wrap(err, ErrInvalidCommitmentSignature.Error())
-		}
-
-		// convert public key to ethereum address.
-		address := crypto.PubkeyToAddress(*pubkey)
-		authorityLeaf := merkletypes.Leaf{
-			Hash:  crypto.Keccak256(address[:]),
-			Index: uint64(signature.AuthorityIndex),
-		}
-		authorityLeaves = append(authorityLeaves, authorityLeaf)
-	}
-
-	// flag for if the authority set has been updated
-	updatedAuthority := false
-
-	// assert that known authorities signed this commitment, only 2 cases because we already
-	// made a prior check to assert that authorities are known
-	switch signedCommitment.Commitment.ValidatorSetId {
-	case cs.Authority.Id:
-		// here we construct a merkle proof, and verify that the public keys which produced this signature
-		// are part of the current round.
-		authoritiesProof := merkle.NewProof(authorityLeaves, authoritiesProof, uint64(cs.Authority.Len), hasher.Keccak256Hasher{})
-		valid, err := authoritiesProof.Verify(cs.Authority.AuthorityRoot[:])
-		if err != nil || !valid {
-			return sdkerrors.Wrap(err, ErrAuthoritySetUnknown.Error())
-		}
-
-	// new authority set has kicked in
-	case cs.NextAuthoritySet.Id:
-		authoritiesProof := merkle.NewProof(authorityLeaves, authoritiesProof, uint64(cs.NextAuthoritySet.Len), hasher.Keccak256Hasher{})
-		valid, err := authoritiesProof.Verify(cs.NextAuthoritySet.AuthorityRoot[:])
-		if err != nil || !valid {
-			return sdkerrors.Wrap(err, ErrAuthoritySetUnknown.Error())
-		}
-		updatedAuthority = true
+		return err
 	}
 
 	// only update if we have a higher block number.
 	if signedCommitment.Commitment.BlockNumer > cs.LatestBeefyHeight {
 		for _, payload := range signedCommitment.Commitment.Payload {
-			mmrRootID := []byte("mh")
 			// checks for the right payloadId
-			if bytes.Equal(payload.PayloadId[:], mmrRootID) {
+			if bytes.Equal(payload.PayloadId[:], MmrRootPayloadID) {
 				// the next authorities are in the latest BeefyMmrLeaf
 
 				// scale encode the mmr leaf
@@ -142,7 +83,7 @@ func (cs *ClientState) verifyHeader(
 				mmrProof := mmr.NewProof(mmrSize, clientState.MmrProof, mmrLeaves, hasher.Keccak256Hasher{})
 				// verify that the leaf is valid, for the signed mmr-root-hash
 				if !mmrProof.Verify(payload.PayloadData) {
-					return sdkerrors.Wrap(err, ErrFailedVerifyMMRLeaf.Error()) // error!, mmr proof is invalid
+					return ErrFailedVerifyMMRLeaf // error!, mmr proof is invalid
 				}
 				// update the block_number
 				cs.LatestBeefyHeight = signedCommitment.Commitment.BlockNumer
@@ -159,21 +100,107 @@ func (cs *ClientState) verifyHeader(
 		}
 	}
 
+	// Given the leaves, we should be able to verify that each parachain header was
+	// indeed included in the leaves of our mmr.
+	return cs.verifyParachainHeaders(beefyHeader, cs.MmrRootHash)
+}
+
+// verifySignedCommitment checks that a supermajority of a known authority set signed the commitment,
+// it returns true if the commitment was signed by the next authority set.
+func (cs ClientState) verifySignedCommitment(signedCommitment *SignedCommitment, authoritiesProof [][]byte) (bool, error) {
+	if signedCommitment == nil || signedCommitment.Commitment == nil {
+		return false, sdkerrors.Wrap(ErrInvalidCommitment, "signed commitment cannot be empty")
+	}
+
+	if cs.Authority == nil || cs.NextAuthoritySet == nil {
+		return false, sdkerrors.Wrap(ErrInvalidAuthoritySet, "client state is missing its authority sets")
+	}
+
+	if signedCommitment.Commitment.ValidatorSetId != cs.Authority.Id &&
+		signedCommitment.Commitment.ValidatorSetId != cs.NextAuthoritySet.Id {
+		return false, ErrAuthoritySetUnknown
+	}
+
+	// assert that known authorities signed this commitment, only 2 cases because we already
+	// made a prior check to assert that authorities are known
+	authoritySet, updatedAuthority := cs.Authority, false
+	if signedCommitment.Commitment.ValidatorSetId != cs.Authority.Id {
+		// new authority set has kicked in
+		authoritySet, updatedAuthority = cs.NextAuthoritySet, true
+	}
+
+	// repeated or out of range authority indices would inflate the number of signatures, once the indices
+	// are strictly increasing and in range every signature counts for a distinct authority of the set.
+	if err := validateAuthorityIndices(signedCommitment.Signatures, authoritySet.Len); err != nil {
+		return false, err
+	}
+
+	// checking signatures is expensive (667 authorities for kusama),
+	// we want to know if these sigs meet the minimum threshold of the signing set before proceeding
+	if threshold := cs.authoritiesThreshold(*authoritySet); threshold > uint32(len(signedCommitment.Signatures)) {
+		return false, sdkerrors.Wrapf(ErrCommitmentNotFinal, "%d signatures are below the threshold of %d for authority set %d",
+			len(signedCommitment.Signatures), threshold, authoritySet.Id)
+	}
+
+	// beefy authorities are signing the hash of the scale-encoded Commitment
+	commitmentBytes, err := rpcclienttypes.Encode(signedCommitment.Commitment)
+	if err != nil {
+		return false, sdkerrors.Wrap(err, ErrInvalidCommitment.Error())
+	}
+
+	// take keccak hash of the commitment scale-encoded
+	commitmentHash := crypto.Keccak256(commitmentBytes)
+
+	scheme, err := cs.signatureScheme()
+	if err != nil {
+		return false, err
+	}
+
+	// array of leaves in the authority merkle root, in the order of the signatures.
+	authorityLeaves, err := scheme.VerifySignatures(commitmentHash, signedCommitment)
+	if err != nil {
+		return false, err
+	}
+
+	// here we construct a merkle proof, and verify that the public keys which produced this signature
+	// are part of the authority set.
+	proof := merkle.NewProof(authorityLeaves, authoritiesProof, uint64(authoritySet.Len), hasher.Keccak256Hasher{})
+	valid, err := proof.Verify(authoritySet.AuthorityRoot[:])
+	if err != nil {
+		return false, sdkerrors.Wrap(ErrAuthoritySetUnknown, err.Error())
+	}
+	if !valid {
+		return false, sdkerrors.Wrapf(ErrAuthoritySetUnknown, "signatures are not from authority set %d", authoritySet.Id)
+	}
+
+	return updatedAuthority, nil
+}
+
+// mmrRootPayload returns the mmr root hash signed in the commitment, if any.
+func mmrRootPayload(commitment *Commitment) ([]byte, bool) {
+	for _, payload := range commitment.Payload {
+		if payload != nil && payload.PayloadId != nil && bytes.Equal(payload.PayloadId[:], MmrRootPayloadID) {
+			return payload.PayloadData, true
+		}
+	}
+
+	return nil, false
+}
+
+// verifyParachainHeaders proves that every parachain header of the Header was included in the leaves
+// of the mmr with the given root.
+func (cs ClientState) verifyParachainHeaders(beefyHeader *Header, mmrRoot []byte) error {
 	mmrProof, err := cs.parachainHeadersToMMRProof(beefyHeader)
 	if err != nil {
 		return sdkerrors.Wrap(err, "failed to execute getMMRProf")
 	}
 
-	// Given the leaves, we should be able to verify that each parachain header was
-	// indeed included in the leaves of our mmr.
-	if !mmrProof.Verify(cs.MmrRootHash) {
+	if !mmrProof.Verify(mmrRoot) {
 		root, err := mmrProof.CalculateRoot()
 		if err != nil {
-			log15.Error(fmt.Sprintf("failed to calculate root for mmr leaf %v", root))
-			return sdkerrors.Wrap(err, ErrFailedEncodeMMRLeaf.Error())
+			return sdkerrors.Wrap(ErrFailedEncodeMMRLeaf, err.Error())
 		}
-		log15.Error(fmt.Sprintf("failed to verify mmr leaf %v", root))
-		return sdkerrors.Wrap(err, ErrFailedVerifyMMRLeaf.Error())
+		return sdkerrors.Wrapf(ErrFailedVerifyMMRLeaf, "calculated mmr root %x does not match %x", root, mmrRoot)
 	}
 
 	return nil
@@ -185,15 +212,32 @@ type ParaIdAndHeader struct {
 	Header []byte
 }
 
-func (cs *ClientState) parachainHeadersToMMRProof(beefyHeader *Header) (*mmr.Proof, error) {
-	mmrLeaves := make([]merkletypes.Leaf, len(beefyHeader.ConsensusStateUpdate.ParachainHeaders))
+func (cs ClientState) parachainHeadersToMMRProof(beefyHeader *Header) (*mmr.Proof, error) {
+	decodedHeaders, err := beefyHeader.DecodeParachainHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	mmrLeaves := make([]merkletypes.Leaf, len(decodedHeaders))
 
 	// verify parachain headers
-	for i := 0; i < len(beefyHeader.ConsensusStateUpdate.ParachainHeaders); i++ {
+	for i, decodedHeader := range decodedHeaders {
 		// first we need to reconstruct the mmr leaf for this header
-		parachainHeader := beefyHeader.ConsensusStateUpdate.ParachainHeaders[i]
+		parachainHeader := decodedHeader.ParachainHeader
+		if parachainHeader.MmrLeafPartial == nil {
+			return nil, sdkerrors.Wrapf(ErrInvalidParachainHeader, "parachain header %d must contain a partial mmr leaf", i)
+		}
 
-		headsLeafBytes, err := rpcclienttypes.Encode(ParaIdAndHeader{ParaId: cs.ParaId, Header: parachainHeader.ParachainHeader})
+		paraId := parachainHeader.ParaId
+		if !cs.IsTrackedParaId(paraId) {
+			return nil, sdkerrors.Wrapf(ErrInvalidParaID, "parachain header %d is for para id %d, which is not tracked by the client", i, paraId)
+		}
+
+		if err := cs.verifyTimestampPallet(decodedHeader); err != nil {
+			return nil, sdkerrors.Wrapf(err, "parachain header %d", i)
+		}
+
+		headsLeafBytes, err := rpcclienttypes.Encode(ParaIdAndHeader{ParaId: paraId, Header: parachainHeader.ParachainHeader})
 		if err != nil {
 			return nil, sdkerrors.Wrap(err, ErrInvalivParachainHeadsProof.Error())
 		}
@@ -206,7 +250,6 @@ func (cs *ClientState) parachainHeadersToMMRProof(beefyHeader *Header) (*mmr.Pro
 		parachainHeadsProof := merkle.NewProof(headsLeaf, parachainHeader.ParachainHeadsProof, uint64(parachainHeader.HeadsTotalCount), hasher.Keccak256Hasher{})
 		// todo: merkle.Proof.Root() should return fixed bytes
 		parachainHeadsRoot, err := parachainHeadsProof.Root()
-		// TODO: verify extrinsic root here once trie lib is fixed.
 		if err != nil {
 			return nil, sdkerrors.Wrap(err, ErrInvalivParachainHeadsProof.Error())
 		}
@@ -247,116 +290,42 @@ func (cs *ClientState) parachainHeadersToMMRProof(beefyHeader *Header) (*mmr.Pro
 	return mmrProof, nil
 }
 
-// TODO: implement UpdateState which returns type []exported.Height
-/*func (cs ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, clientMsg exported.ClientMessage) error {
-	beefyHeader, ok := clientMsg.(*Header)
-	if !ok {
-		return sdkerrors.Wrapf(clienttypes.ErrInvalidClientType, "expected type %T, got %T", &Header{}, beefyHeader)
-	}
-
-	consensusStates := make(map[clienttypes.Height]*ConsensusState)
-
-	// iterate over each parachain header and set them in the store.
-	for _, v := range beefyHeader.ParachainHeaders {
-		// decode parachain header bytes to struct
-		header, err := DecodeParachainHeader(v.ParachainHeader)
-		if err != nil {
-			return sdkerrors.Wrap(err, "failed to decode parachain header")
-		}
-
-		// TODO: IBC should allow height to be generic
-		height := clienttypes.Height{
-			// revion number is used to store paraId
-			RevisionNumber: uint64(v.ParaId),
-			RevisionHeight: uint64(header.Number),
-		}
-
-		// check for duplicate consensus state
-		if consensusState, _ := GetConsensusState(clientStore, cdc, height); consensusState != nil {
-			// perform no-op
-			continue
-		}
-
-		trieProof := trie.NewEmptyTrie()
-		// load the extrinsics proof which is basically a partial trie
-		// that encodes the timestamp extrinsic
-		errr := trieProof.LoadFromProof(v.ExtrinsicProof, header.ExtrinsicsRoot[:])
-		if errr != nil {
-			return sdkerrors.Wrap(err, "failed to load extrinsic proof")
-		}
-		// the timestamp extrinsic is stored under the key 0u32 in big endian
-		key := make([]byte, 4)
-		timestamp, err := DecodeExtrinsicTimestamp(trieProof.Get(key))
-
+// CheckForMisbehaviour detects BFT time violation misbehaviour, and returns true for verified Misbehaviour.
+// Parachain headers at heights the client already has a consensus state for are duplicates, which
+// UpdateState ignores, since a differing consensus state alone is not evidence of a parachain fork.
+func (cs ClientState) CheckForMisbehaviour(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, msg exported.Header) bool {
+	switch msg := msg.(type) {
+	case *Header:
+		decodedHeaders, err := msg.DecodeParachainHeaders()
 		if err != nil {
-			return sdkerrors.Wrap(err, "failed to decode timestamp extrinsic")
+			return false
 		}
 
-		var ibcCommitmentRoot []byte
-		// IBC commitment root is stored in the header digests as a ConsensusItem
-		for _, v := range header.Digest {
-			if v.IsConsensus {
-
-				consensusID := v.AsConsensus.ConsensusEngineID
-
-				// this is a constant that comes from pallet-ibc
-				if bytes.Equal(consensusID[:], []byte("/IBC")) {
-					ibcCommitmentRoot = v.AsConsensus.Bytes
-				}
+		for _, parachainHeader := range decodedHeaders {
+			height, consState, err := cs.consensusStateFromParachainHeader(parachainHeader)
+			if err != nil {
+				// the header has been verified by VerifyClientMessage, so this can't be evidence of misbehaviour
+				continue
 			}
-		}
-
-		consensusStates[height] = &ConsensusState{
-			Timestamp: timestamp,
-			Root:      ibcCommitmentRoot,
-		}
-	}
 
-	// only set consensus states after doing checks
-	for height, consensusState := range consensusStates {
-		// we store consensus state as (PARA_ID, HEIGHT) => ConsensusState
-		setConsensusState(clientStore, cdc, consensusState, height)
-
-		// TODO: pruning!
-	}
-
-	setClientState(clientStore, cdc, &cs)
-
-	return nil
-}*/
-
-// CheckForMisbehaviour detects duplicate height misbehaviour and BFT time violation misbehaviour
-func (cs ClientState) CheckForMisbehaviour(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, msg exported.Header) bool {
-	switch msg := msg.(type) {
-	case *Header:
-		tmHeader := msg
-		consState := tmHeader.ConsensusState()
-
-		// Check if the Client store already has a consensus state for the header's height
-		// If the consensus state exists, and it matches the header then we return early
-		// since header has already been submitted in a previous UpdateClient.
-		prevConsState, _ := GetConsensusState(clientStore, cdc, tmHeader.GetHeight())
-		if prevConsState != nil {
-			// return false if this header has already been submitted and the necessary state is already stored
-			// in client store, thus we can return early without further validation.
-			// return true if a consensus state already exists for this height, but it does not match the provided
-			// header. The assumption is that Header has already been validated. Thus we can return true as misbehaviour
-			// is present
-			return !reflect.DeepEqual(prevConsState, tmHeader.ConsensusState())
-		}
+			// this header has already been submitted and the necessary state is already stored
+			if prevConsState, _ := GetConsensusState(clientStore, cdc, height); prevConsState != nil {
+				continue
+			}
 
-		// Check that consensus state timestamps are monotonic
-		prevCons, prevOk := GetPreviousConsensusState(clientStore, cdc, tmHeader.GetHeight())
-		nextCons, nextOk := GetNextConsensusState(clientStore, cdc, tmHeader.GetHeight())
-		// if previous consensus state exists, check consensus state time is greater than previous consensus state time
-		// if previous consensus state is not before current consensus state return true
-		if prevOk && !prevCons.Timestamp.Before(consState.Timestamp) {
-			return true
-		}
-		// if next consensus state exists, check consensus state time is less than next consensus state time
-		// if next consensus state is not after current consensus state return true
-		if nextOk && !nextCons.Timestamp.After(consState.Timestamp) {
-			return true
+			// Check that consensus state timestamps are monotonic
+			prevCons, prevOk := GetPreviousConsensusState(clientStore, cdc, height)
+			nextCons, nextOk := GetNextConsensusState(clientStore, cdc, height)
+			// if previous consensus state exists, check consensus state time is greater than previous consensus state time
+			// if previous consensus state is not before current consensus state return true
+			if prevOk && !prevCons.Timestamp.Before(consState.Timestamp) {
+				return true
+			}
+			// if next consensus state exists, check consensus state time is less than next consensus state time
+			// if next consensus state is not after current consensus state return true
+			if nextOk && !nextCons.Timestamp.After(consState.Timestamp) {
+				return true
+			}
 		}
 	case *Misbehaviour:
 		// The correctness of Misbehaviour ClientMessage types is ensured by calling VerifyClientMessage prior to this function
@@ -398,30 +367,260 @@ func (cs ClientState) GetLeafIndexForBlockNumber(blockNumber uint32) uint32 {
 	return leafIndex
 }
 
-func authoritiesThreshold(authoritySet BeefyAuthoritySet) uint32 {
-	return 2*authoritySet.Len/3 + 1
+// authoritiesThreshold returns the number of authorities of the set that must sign a commitment for it to be
+// final, which is the smallest number above the supermajority of the client.
+func (cs ClientState) authoritiesThreshold(authoritySet BeefyAuthoritySet) uint32 {
+	supermajority := cs.supermajority()
+	return uint32(uint64(supermajority.Numerator)*uint64(authoritySet.Len)/uint64(supermajority.Denominator)) + 1
+}
+
+// supermajority returns the supermajority of the client, which is the supermajority of the BEEFY protocol
+// unless the client was created with another one.
+func (cs ClientState) supermajority() Supermajority {
+	if cs.Supermajority == (Supermajority{}) {
+		return defaultSupermajority()
+	}
+	return cs.Supermajority
 }
 
 // UpdateStateOnMisbehaviour updates state upon misbehaviour, freezing the ClientState. This method should only be called when misbehaviour is detected
 // as it does not perform any misbehaviour checks.
-func (cs ClientState) UpdateStateOnMisbehaviour(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, _ exported.Header) {
-	clientStore.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(cdc, &cs))
+func (cs *ClientState) UpdateStateOnMisbehaviour(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, _ exported.Header) {
+	// use the same frozen height for all misbehaviour, so every validator freezes the client identically
+	cs.FrozenHeight = FrozenHeight.GetRevisionHeight()
 
-	panic("implement me")
+	setClientState(clientStore, cdc, cs)
 }
 
-func (cs *ClientState) UpdateState(context sdk.Context, codec codec.BinaryCodec, store sdk.KVStore, message exported.Header) []exported.Height {
-	panic("implement me")
+// UpdateState persists a ConsensusState for every parachain header in the Header and returns the heights
+// they were stored at. It assumes the Header has already been verified by VerifyClientMessage, which also
+// updates the mmr root hash and authority sets of the ClientState.
+func (cs *ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, clientMsg exported.Header) []exported.Height {
+	beefyHeader, ok := clientMsg.(*Header)
+	if !ok {
+		panic(fmt.Errorf("expected type %T, got %T", &Header{}, clientMsg))
+	}
+
+	var (
+		heights         []exported.Height
+		consensusStates []*ConsensusState
+	)
+
+	headerHeights, headerConsensusStates, err := beefyHeader.ConsensusStates(*cs)
+	if err != nil {
+		panic(sdkerrors.Wrap(err, "failed to derive consensus states from verified header"))
+	}
+
+	seen := make(map[exported.Height]bool)
+	for i, height := range headerHeights {
+		// check for duplicate consensus states, both in the header and in the store
+		if seen[height] {
+			continue
+		}
+		seen[height] = true
+
+		if prevConsState, _ := GetConsensusState(clientStore, cdc, height); prevConsState != nil {
+			// perform no-op
+			continue
+		}
+
+		heights = append(heights, height)
+		consensusStates = append(consensusStates, headerConsensusStates[i])
+	}
+
+	// only set consensus states after doing checks
+	for i, height := range heights {
+		// we store consensus state as HEIGHT => ConsensusState
+		setConsensusState(clientStore, cdc, consensusStates[i], height)
+		setConsensusMetadata(ctx, clientStore, height)
+
+		// the latest height is only tracked for the parachain of the client
+		if height.GetRevisionNumber() == uint64(cs.ParaId) && uint32(height.GetRevisionHeight()) > cs.LatestParaHeight {
+			cs.LatestParaHeight = uint32(height.GetRevisionHeight())
+		}
+	}
+
+	cs.pruneConsensusStates(ctx, cdc, clientStore)
+
+	setClientState(clientStore, cdc, cs)
+
+	return heights
 }
 
-func (cs *ClientState) VerifyMembership(ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, proof []byte, path []byte, value []byte) error {
-	panic("implement me")
+// pruneConsensusStates walks the consensus states of every tracked parachain in ascending order and deletes the
+// ones that are older than the retention period, or in excess of the maximum number of consensus states of that
+// parachain, together with their metadata. At most MaxPrunedConsensusStatesPerUpdate are deleted, so that the cost
+// of an update stays bounded, and the latest consensus state of a parachain is never deleted.
+func (cs ClientState) pruneConsensusStates(ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore) {
+	if cs.ConsensusStateRetentionPeriod == 0 && cs.MaxConsensusStates == 0 {
+		return
+	}
+
+	// the heights are collected first, since the store can't be written to while it is iterated over
+	var heights []exported.Height
+	for _, paraId := range cs.TrackedParaIds() {
+		limit := MaxPrunedConsensusStatesPerUpdate - len(heights)
+		if limit <= 0 {
+			break
+		}
+		heights = append(heights, cs.prunableConsensusStates(ctx, cdc, clientStore, paraId, limit)...)
+	}
+
+	for _, height := range heights {
+		deleteConsensusState(clientStore, height)
+	}
 }
 
-func (cs *ClientState) VerifyNonMembership(ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, proof []byte, path []byte) error {
-	panic("implement me")
+// prunableConsensusStates returns up to limit heights of the given parachain whose consensus states can be pruned.
+func (cs ClientState) prunableConsensusStates(
+	ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore, paraId uint32, limit int,
+) []exported.Height {
+	var excess int
+	if cs.MaxConsensusStates > 0 {
+		var count int
+		IterateParachainConsensusStates(clientStore, paraId, func(_ exported.Height) bool {
+			count++
+			return false
+		})
+		excess = count - int(cs.MaxConsensusStates)
+	}
+
+	var (
+		heights []exported.Height
+		// whether a consensus state after the collected ones is kept
+		kept bool
+	)
+	IterateParachainConsensusStates(clientStore, paraId, func(height exported.Height) bool {
+		if len(heights) >= limit {
+			kept = true
+			return true
+		}
+
+		if len(heights) < excess {
+			heights = append(heights, height)
+			return false
+		}
+
+		consensusState, err := GetConsensusState(clientStore, cdc, height)
+		if err != nil || cs.ConsensusStateRetentionPeriod == 0 {
+			kept = true
+			return true
+		}
+
+		// consensus states are ordered by height, so the following ones are at least as recent
+		if consensusState.Timestamp.Add(cs.ConsensusStateRetentionPeriod).After(ctx.BlockTime()) {
+			kept = true
+			return true
+		}
+
+		heights = append(heights, height)
+		return false
+	})
+
+	// keep the latest consensus state of the parachain
+	if !kept && len(heights) > 0 {
+		heights = heights[:len(heights)-1]
+	}
+
+	return heights
+}
+
+// consensusStateFromParachainHeader derives the ConsensusState for the decoded parachain header, along with
+// the height it should be stored at.
+func (cs ClientState) consensusStateFromParachainHeader(parachainHeader DecodedParachainHeader) (exported.Height, *ConsensusState, error) {
+	header := parachainHeader.Header
+
+	if err := cs.verifyTimestampPallet(parachainHeader); err != nil {
+		return nil, nil, err
+	}
+
+	height := ParachainHeight(parachainHeader.ParaId, uint32(header.Number))
+
+	root, err := cs.commitmentRoot(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return height, &ConsensusState{
+		Timestamp: parachainHeader.Timestamp,
+		Root:      root,
+	}, nil
+}
+
+// commitmentRoot returns the root the ibc state of the parachain header is proven against. This is either the
+// parachain state root, under which pallet-ibc keeps its child trie, or the root of that child trie, which
+// pallet-ibc deposits in the header digests as a ConsensusItem.
+func (cs ClientState) commitmentRoot(header rpcclienttypes.Header) ([]byte, error) {
+	if cs.RootSource != RootSource_IBC_DIGEST {
+		return header.StateRoot[:], nil
+	}
+
+	for _, digest := range header.Digest {
+		if !digest.IsConsensus {
+			continue
+		}
+
+		consensusID := digest.AsConsensus.ConsensusEngineID
+		// this is a constant that comes from pallet-ibc
+		if bytes.Equal(consensusID[:], IBCConsensusEngineID) {
+			if len(digest.AsConsensus.Bytes) == 0 {
+				break
+			}
+			return digest.AsConsensus.Bytes, nil
+		}
+	}
+
+	return nil, sdkerrors.Wrapf(ErrInvalidRootHash, "ibc commitment root not found in header #%d digest", header.Number)
 }
 
+// verifyTimestampPallet checks that the timestamp extrinsic of the parachain header calls pallet-timestamp of
+// the parachain, whose index in the runtime is set by the client state.
+func (cs ClientState) verifyTimestampPallet(parachainHeader DecodedParachainHeader) error {
+	palletIndex := cs.timestampPalletIndex(parachainHeader.ParaId)
+	if parachainHeader.TimestampPalletIndex != palletIndex {
+		return sdkerrors.Wrapf(ErrInvalidTimestampPallet, "expected a call to pallet-timestamp %d of parachain %d, got pallet %d",
+			palletIndex, parachainHeader.ParaId, parachainHeader.TimestampPalletIndex)
+	}
+
+	return nil
+}
+
+// timestampFromExtrinsicProof verifies that the TimestampExtrinsic of the parachain header is the first extrinsic
+// committed to by the extrinsics root of the header, and decodes the timestamp it sets along with the index of the
+// pallet it calls.
+func timestampFromExtrinsicProof(parachainHeader DecodedParachainHeader) (time.Time, uint8, error) {
+	// the timestamp extrinsic is stored under the key 0u32
+	key := make([]byte, 4)
+	err := verifyTrieMembership(parachainHeader.ExtrinsicProof, parachainHeader.Header.ExtrinsicsRoot[:], key, parachainHeader.TimestampExtrinsic)
+	if err != nil {
+		return time.Time{}, 0, sdkerrors.Wrapf(ErrInvalidExtrinsicProof, "failed to verify the timestamp extrinsic: %s", err)
+	}
+
+	timestamp, palletIndex, err := DecodeExtrinsicTimestamp(parachainHeader.TimestampExtrinsic)
+	if err != nil {
+		return time.Time{}, 0, sdkerrors.Wrap(err, "failed to decode timestamp extrinsic")
+	}
+
+	return timestamp, palletIndex, nil
+}
+
+// GetTimestampAtHeight returns the timestamp in nanoseconds of the consensus state at the given parachain height.
+// A frozen, expired or otherwise inactive client returns an error, since its consensus states can no longer be trusted.
 func (cs *ClientState) GetTimestampAtHeight(ctx sdk.Context, clientStore sdk.KVStore, cdc codec.BinaryCodec, height exported.Height) (uint64, error) {
-	panic("implement me")
+	switch status := cs.Status(ctx, clientStore, cdc); status {
+	case exported.Active:
+	case exported.Frozen:
+		return 0, sdkerrors.Wrapf(clienttypes.ErrClientFrozen, "cannot get timestamp of a client frozen at height %d", cs.FrozenHeight)
+	case exported.Expired:
+		return 0, sdkerrors.Wrapf(ErrClientExpired, "cannot get timestamp of a client whose trusting period of %s has passed", cs.TrustingPeriod)
+	default:
+		return 0, sdkerrors.Wrapf(clienttypes.ErrClientNotActive, "cannot get timestamp of a client with status %s", status)
+	}
+
+	consensusState, err := GetConsensusState(clientStore, cdc, height)
+	if err != nil {
+		return 0, err
+	}
+
+	return consensusState.GetTimestamp(), nil
 }
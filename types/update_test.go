@@ -3,15 +3,29 @@ package types_test
 import (
 	"context"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"sort"
 	"testing"
-
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v5/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/ComposableFi/go-merkle-trees/hasher"
 	"github.com/ComposableFi/go-merkle-trees/merkle"
+	"github.com/ComposableFi/go-merkle-trees/mmr"
 	client "github.com/ComposableFi/go-substrate-rpc-client/v4"
 	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
 	beefytypes "github.com/ComposableFi/ics11-beefy/types"
@@ -255,6 +269,7 @@ func TestCheckHeaderAndUpdateState(t *testing.T) {
 
 				header := beefytypes.ParachainHeader{
 					ParachainHeader: paraHeaders[PARA_ID],
+					ParaId:          PARA_ID,
 					MmrLeafPartial: &beefytypes.BeefyMmrLeafPartial{
 						Version:      beefytypes.U8(v.Leaf.Version),
 						ParentNumber: uint32(v.Leaf.ParentNumberAndHash.ParentNumber),
@@ -470,3 +485,724 @@ func fetchParaIDs(conn *client.SubstrateAPI, blockHash rpcclienttypes.Hash) ([]u
 
 	return paraIds, nil
 }
+
+func TestUpdateState(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	ibcRoot := crypto.Keccak256([]byte("ibc root"))
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, ParaId: PARA_ID}
+
+	var parachainHeaders []*beefytypes.ParachainHeader
+	for _, number := range []uint32{10, 11, 11} {
+		parachainHeaders = append(parachainHeaders, newTestParachainHeader(t, number, stateRoot, ibcRoot))
+	}
+
+	heights := clientState.UpdateState(ctx, cdc, clientStore, &beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{ParachainHeaders: parachainHeaders},
+	})
+	require.Len(t, heights, 2, "duplicate parachain headers should only be stored once")
+	require.Equal(t, uint32(11), clientState.LatestParaHeight)
+
+	for _, height := range heights {
+		consensusState, err := beefytypes.GetConsensusState(clientStore, cdc, height)
+		require.NoError(t, err)
+		require.Equal(t, stateRoot[:], consensusState.Root)
+		require.Equal(t, int64(1643972151006), consensusState.Timestamp.UnixMilli())
+		require.NotNil(t, clientStore.Get(beefytypes.ProcessedTimeKey(height)))
+		require.NotNil(t, clientStore.Get(beefytypes.ProcessedHeightKey(height)))
+		require.NotNil(t, clientStore.Get(beefytypes.IterationKey(height)))
+	}
+
+	// already stored consensus states are not overwritten
+	heights = clientState.UpdateState(ctx, cdc, clientStore, &beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{ParachainHeaders: parachainHeaders[:1]},
+	})
+	require.Empty(t, heights)
+}
+
+func TestHeaderConsensusStates(t *testing.T) {
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	ibcRoot := crypto.Keccak256([]byte("ibc root"))
+
+	header := beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+			ParachainHeaders: []*beefytypes.ParachainHeader{
+				newTestParachainHeader(t, 10, stateRoot, ibcRoot),
+				newTestParachainHeader(t, 11, stateRoot, ibcRoot),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		rootSource beefytypes.RootSource
+		expRoot    []byte
+	}{
+		{"state root", beefytypes.RootSource_STATE_ROOT, stateRoot[:]},
+		{"ibc digest", beefytypes.RootSource_IBC_DIGEST, ibcRoot},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientState := beefytypes.ClientState{ParaId: PARA_ID, RootSource: tc.rootSource}
+
+			heights, consensusStates, err := header.ConsensusStates(clientState)
+			require.NoError(t, err)
+			require.Len(t, consensusStates, 2, "every parachain header should have a consensus state")
+
+			for i, consensusState := range consensusStates {
+				require.Equal(t, beefytypes.ParachainHeight(PARA_ID, uint32(10+i)), heights[i])
+				require.Equal(t, tc.expRoot, consensusState.Root)
+				require.Equal(t, int64(1643972151006), consensusState.Timestamp.UnixMilli())
+				require.NoError(t, consensusState.ValidateBasic())
+			}
+		})
+	}
+
+	// the ibc commitment root is mandatory when the client reads it from the digest
+	header.ConsensusStateUpdate.ParachainHeaders[1] = newTestParachainHeader(t, 11, stateRoot, nil)
+	_, _, err := header.ConsensusStates(beefytypes.ClientState{ParaId: PARA_ID, RootSource: beefytypes.RootSource_IBC_DIGEST})
+	require.ErrorIs(t, err, beefytypes.ErrInvalidRootHash)
+
+	_, consensusStates, err := header.ConsensusStates(beefytypes.ClientState{ParaId: PARA_ID})
+	require.NoError(t, err)
+	require.Len(t, consensusStates, 2)
+}
+
+func TestHeaderConsensusStatesTimestampPallet(t *testing.T) {
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+
+	// a timestamp.set call to pallet 4
+	timestampExtrinsic, err := hex.DecodeString("280404000bde4660c47e01")
+	require.NoError(t, err)
+	parachainHeader := newTestParachainHeaderWithExtrinsic(t, 10, stateRoot, nil, timestampExtrinsic)
+	otherParachainHeader := newTestParachainHeaderWithExtrinsic(t, 10, stateRoot, nil, timestampExtrinsic)
+	otherParachainHeader.ParaId = PARA_ID + 1
+
+	testCases := []struct {
+		name        string
+		header      *beefytypes.ParachainHeader
+		clientState beefytypes.ClientState
+		expErr      error
+	}{
+		{"default timestamp pallet", parachainHeader, beefytypes.ClientState{ParaId: PARA_ID}, beefytypes.ErrInvalidTimestampPallet},
+		{"timestamp pallet of the client", parachainHeader, beefytypes.ClientState{ParaId: PARA_ID, TimestampPalletIndex: 4}, nil},
+		{"other timestamp pallet of the client", parachainHeader, beefytypes.ClientState{ParaId: PARA_ID, TimestampPalletIndex: 5}, beefytypes.ErrInvalidTimestampPallet},
+		{"timestamp pallet of an additional parachain", otherParachainHeader, beefytypes.ClientState{
+			ParaId:               PARA_ID,
+			AdditionalParachains: []beefytypes.AdditionalParachain{{ParaId: PARA_ID + 1, TimestampPalletIndex: 4}},
+		}, nil},
+		{"timestamp pallet of the client for an additional parachain", otherParachainHeader, beefytypes.ClientState{
+			ParaId:               PARA_ID,
+			TimestampPalletIndex: 4,
+			AdditionalParachains: []beefytypes.AdditionalParachain{{ParaId: PARA_ID + 1}},
+		}, beefytypes.ErrInvalidTimestampPallet},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			header := &beefytypes.Header{
+				ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{ParachainHeaders: []*beefytypes.ParachainHeader{tc.header}},
+			}
+			require.NoError(t, header.ValidateBasic())
+
+			_, consensusStates, err := header.ConsensusStates(tc.clientState)
+			if tc.expErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, int64(1643972151006), consensusStates[0].Timestamp.UnixMilli())
+			} else {
+				require.ErrorIs(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func newTestClientStore(t *testing.T) (sdk.Context, codec.BinaryCodec, sdk.KVStore) {
+	t.Helper()
+
+	registry := codectypes.NewInterfaceRegistry()
+	clienttypes.RegisterInterfaces(registry)
+	beefytypes.RegisterInterfaces(registry)
+
+	ctx := sdk.Context{}.
+		WithChainID("testchain-1").
+		WithBlockHeight(100).
+		WithBlockTime(time.Unix(1643972160, 0))
+
+	return ctx, codec.NewProtoCodec(registry), dbadapter.Store{DB: dbm.NewMemDB()}
+}
+
+// newTestParachainHeader builds a scale-encoded parachain header whose extrinsics root commits to a
+// timestamp extrinsic, and whose digest contains the given ibc commitment root.
+func newTestParachainHeader(t *testing.T, number uint32, stateRoot beefytypes.SizedByte32, ibcRoot []byte) *beefytypes.ParachainHeader {
+	t.Helper()
+
+	timestampExtrinsic, err := hex.DecodeString("280403000bde4660c47e01")
+	require.NoError(t, err)
+
+	return newTestParachainHeaderWithExtrinsic(t, number, stateRoot, ibcRoot, timestampExtrinsic)
+}
+
+// newTestParachainHeaderWithExtrinsic is like newTestParachainHeader, but commits to the given timestamp extrinsic.
+func newTestParachainHeaderWithExtrinsic(
+	t *testing.T, number uint32, stateRoot beefytypes.SizedByte32, ibcRoot []byte, timestampExtrinsic []byte,
+) *beefytypes.ParachainHeader {
+	t.Helper()
+
+	extrinsicsTrie := trie.NewEmptyTrie()
+	extrinsicsTrie.Put(make([]byte, 4), timestampExtrinsic)
+	for i := 1; i < 4; i++ {
+		key := make([]byte, 4)
+		key[3] = byte(i)
+		extrinsicsTrie.Put(key, crypto.Keccak256([]byte{byte(i)}))
+	}
+	extrinsicsRoot, err := extrinsicsTrie.Hash()
+	require.NoError(t, err)
+
+	db, err := chaindb.NewBadgerDB(&chaindb.Config{InMemory: true, DataDir: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, extrinsicsTrie.Store(db))
+
+	extrinsicProof, err := trie.GenerateProof(extrinsicsRoot.ToBytes(), [][]byte{make([]byte, 4)}, db)
+	require.NoError(t, err)
+
+	var engineID rpcclienttypes.ConsensusEngineID
+	copy(engineID[:], beefytypes.IBCConsensusEngineID)
+
+	header := rpcclienttypes.Header{
+		Number:         rpcclienttypes.BlockNumber(number),
+		StateRoot:      rpcclienttypes.Hash(stateRoot),
+		ExtrinsicsRoot: rpcclienttypes.Hash(extrinsicsRoot),
+		Digest: rpcclienttypes.Digest{
+			{
+				IsConsensus: true,
+				AsConsensus: rpcclienttypes.Consensus{ConsensusEngineID: engineID, Bytes: ibcRoot},
+			},
+		},
+	}
+	headerBytes, err := rpcclienttypes.Encode(header)
+	require.NoError(t, err)
+
+	headData, err := rpcclienttypes.Encode(beefytypes.HeadData{Head: headerBytes})
+	require.NoError(t, err)
+
+	return &beefytypes.ParachainHeader{
+		ParachainHeader:    headData,
+		ExtrinsicProof:     extrinsicProof,
+		TimestampExtrinsic: timestampExtrinsic,
+		ParaId:             PARA_ID,
+	}
+}
+
+func TestUpdateStateOnMisbehaviour(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, ParaId: PARA_ID}
+	require.Equal(t, exported.Active, clientState.Status(ctx, clientStore, cdc))
+
+	clientState.UpdateStateOnMisbehaviour(ctx, cdc, clientStore, &beefytypes.Misbehaviour{})
+
+	storedClientState, ok := clienttypes.MustUnmarshalClientState(cdc, clientStore.Get(host.ClientStateKey())).(*beefytypes.ClientState)
+	require.True(t, ok)
+	require.Equal(t, beefytypes.FrozenHeight.GetRevisionHeight(), storedClientState.FrozenHeight)
+	require.Equal(t, exported.Frozen, storedClientState.Status(ctx, clientStore, cdc))
+
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc/"))
+	err := storedClientState.VerifyPacketCommitment(
+		ctx, clientStore, cdc, beefytypes.ParachainHeight(PARA_ID, 10), 0, 0, &prefix, []byte{0}, "transfer", "channel-0", 1, []byte{1},
+	)
+	require.ErrorIs(t, err, clienttypes.ErrClientFrozen)
+}
+
+func TestGetTimestampAtHeight(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	height := beefytypes.ParachainHeight(PARA_ID, 10)
+	consensusState := &beefytypes.ConsensusState{Timestamp: time.Unix(1643972151, 0), Root: []byte("root")}
+	clientStore.Set(host.ConsensusStateKey(height), clienttypes.MustMarshalConsensusState(cdc, consensusState))
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 1, LatestParaHeight: 10, ParaId: PARA_ID}
+
+	timestamp, err := clientState.GetTimestampAtHeight(ctx, clientStore, cdc, height)
+	require.NoError(t, err)
+	require.Equal(t, consensusState.GetTimestamp(), timestamp)
+
+	_, err = clientState.GetTimestampAtHeight(ctx, clientStore, cdc, beefytypes.ParachainHeight(PARA_ID, 11))
+	require.ErrorIs(t, err, clienttypes.ErrConsensusStateNotFound)
+
+	testCases := []struct {
+		name     string
+		malleate func(clientState *beefytypes.ClientState)
+		expErr   error
+	}{
+		{"frozen", func(clientState *beefytypes.ClientState) {
+			clientState.FrozenHeight = beefytypes.FrozenHeight.GetRevisionHeight()
+		}, clienttypes.ErrClientFrozen},
+		{"trusting period passed", func(clientState *beefytypes.ClientState) {
+			clientState.TrustingPeriod = ctx.BlockTime().Sub(consensusState.Timestamp)
+		}, beefytypes.ErrClientExpired},
+		{"latest consensus state not found", func(clientState *beefytypes.ClientState) {
+			clientState.TrustingPeriod = ctx.BlockTime().Sub(consensusState.Timestamp) + time.Hour
+			clientState.LatestParaHeight = 11
+		}, clienttypes.ErrClientNotActive},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			clientState := *clientState
+			tc.malleate(&clientState)
+
+			_, err := clientState.GetTimestampAtHeight(ctx, clientStore, cdc, height)
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+
+	// the consensus state is still available within the trusting period
+	clientState.TrustingPeriod = ctx.BlockTime().Sub(consensusState.Timestamp) + time.Second
+	timestamp, err = clientState.GetTimestampAtHeight(ctx, clientStore, cdc, height)
+	require.NoError(t, err)
+	require.Equal(t, consensusState.GetTimestamp(), timestamp)
+}
+
+func TestUpdateStatePruning(t *testing.T) {
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	header := &beefytypes.Header{
+		ConsensusStateUpdate: &beefytypes.ConsensusStateUpdateProof{
+			ParachainHeaders: []*beefytypes.ParachainHeader{newTestParachainHeader(t, 1000, stateRoot, nil)},
+		},
+	}
+
+	// consensus states at heights [from, to) with the given age relative to the block time
+	type consensusStates struct {
+		from, to uint64
+		age      time.Duration
+	}
+
+	testCases := []struct {
+		name            string
+		retentionPeriod time.Duration
+		maxStates       uint32
+		stored          []consensusStates
+		expHeights      []uint64
+	}{
+		{
+			"no pruning by default", 0, 0,
+			[]consensusStates{{1, 3, time.Hour}},
+			[]uint64{1, 2, 1000},
+		},
+		{
+			"prune by age", 30 * time.Second, 0,
+			[]consensusStates{{1, 4, time.Minute}, {4, 6, time.Second}},
+			[]uint64{4, 5, 1000},
+		},
+		{
+			"prune by count", 0, 3,
+			[]consensusStates{{1, 6, time.Second}},
+			[]uint64{4, 5, 1000},
+		},
+		{
+			"prune by count and age", 30 * time.Second, 3,
+			[]consensusStates{{1, 3, time.Minute}, {3, 5, time.Second}},
+			[]uint64{3, 4, 1000},
+		},
+		{
+			"latest consensus state is kept", time.Nanosecond, 1,
+			[]consensusStates{{1, 3, time.Minute}},
+			[]uint64{1000},
+		},
+		{
+			"pruning is capped per update", 30 * time.Second, 0,
+			[]consensusStates{{1, beefytypes.MaxPrunedConsensusStatesPerUpdate + 3, time.Minute}},
+			[]uint64{beefytypes.MaxPrunedConsensusStatesPerUpdate + 1, beefytypes.MaxPrunedConsensusStatesPerUpdate + 2, 1000},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cdc, clientStore := newTestClientStore(t)
+
+			for _, stored := range tc.stored {
+				for number := stored.from; number < stored.to; number++ {
+					height := beefytypes.ParachainHeight(PARA_ID, uint32(number))
+					clientStore.Set(host.ConsensusStateKey(height), clienttypes.MustMarshalConsensusState(cdc, &beefytypes.ConsensusState{
+						Timestamp: ctx.BlockTime().Add(-stored.age),
+						Root:      stateRoot[:],
+					}))
+					beefytypes.SetProcessedTime(clientStore, height, uint64(ctx.BlockTime().UnixNano()))
+					beefytypes.SetProcessedHeight(clientStore, height, clienttypes.GetSelfHeight(ctx))
+					beefytypes.SetIterationKey(clientStore, height)
+				}
+			}
+
+			clientState := &beefytypes.ClientState{
+				LatestBeefyHeight:             1,
+				ParaId:                        PARA_ID,
+				ConsensusStateRetentionPeriod: tc.retentionPeriod,
+				MaxConsensusStates:            tc.maxStates,
+			}
+			clientState.UpdateState(ctx, cdc, clientStore, header)
+
+			var heights []uint64
+			beefytypes.IterateConsensusStateAscending(clientStore, func(height exported.Height) bool {
+				heights = append(heights, height.GetRevisionHeight())
+				return false
+			})
+			require.Equal(t, tc.expHeights, heights)
+
+			// the metadata of pruned consensus states is deleted as well
+			for _, stored := range tc.stored {
+				for number := stored.from; number < stored.to; number++ {
+					height := beefytypes.ParachainHeight(PARA_ID, uint32(number))
+					_, err := beefytypes.GetConsensusState(clientStore, cdc, height)
+					_, timeErr := beefytypes.GetProcessedTime(clientStore, height)
+					_, heightErr := beefytypes.GetProcessedHeight(clientStore, height)
+					require.Equal(t, err == nil, timeErr == nil)
+					require.Equal(t, err == nil, heightErr == nil)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateStateMultipleParachains(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	authorities := newTestAuthorities(t, 4)
+
+	const otherParaID = PARA_ID + 1
+	header := func(paraId, number uint32) *beefytypes.ParachainHeader {
+		parachainHeader := newTestParachainHeader(t, number, bytes32(crypto.Keccak256([]byte("state root"))), nil)
+		parachainHeader.ParaId = paraId
+		return parachainHeader
+	}
+
+	parachainHeaders := []*beefytypes.ParachainHeader{header(PARA_ID, 10), header(otherParaID, 5), header(otherParaID, 6)}
+
+	store := mmr.NewMemStore()
+	mmrTree := mmr.NewMMR(0, store, nil, hasher.Keccak256Hasher{})
+	var positions []uint64
+	for leafIndex := uint32(0); leafIndex < 4; leafIndex++ {
+		leafHash := crypto.Keccak256([]byte{byte(leafIndex)})
+		if leafIndex > 0 {
+			leafHash = newTestMMRLeafHash(t, leafIndex, parachainHeaders[leafIndex-1])
+			positions = append(positions, mmr.LeafIndexToPos(uint64(leafIndex)))
+		}
+		_, err := mmrTree.Push(leafHash)
+		require.NoError(t, err)
+	}
+	mmrTree.Commit()
+	mmrRoot, err := mmrTree.Root()
+	require.NoError(t, err)
+	proof, err := mmrTree.GenProof(positions)
+	require.NoError(t, err)
+
+	beefyHeader := authorities.signedHeader(t, &beefytypes.Commitment{
+		Payload:        []*beefytypes.PayloadItem{{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: mmrRoot}},
+		BlockNumer:     90,
+		ValidatorSetId: 1,
+	}, []uint32{0, 1, 2, 3})
+	beefyHeader.ConsensusStateUpdate = &beefytypes.ConsensusStateUpdateProof{
+		ParachainHeaders: parachainHeaders,
+		MmrProofs:        proof.ProofItems(),
+		MmrSize:          mmrTree.MMRSize(),
+	}
+
+	clientState := &beefytypes.ClientState{
+		LatestBeefyHeight: 100,
+		MmrRootHash:       mmrRoot,
+		ParaId:            PARA_ID,
+		Authority:         authorities.authoritySet(1),
+		NextAuthoritySet:  authorities.authoritySet(2),
+	}
+
+	// the other parachain isn't tracked yet
+	err = clientState.VerifyClientMessage(ctx, cdc, clientStore, beefyHeader)
+	require.ErrorIs(t, err, beefytypes.ErrInvalidParaID)
+
+	clientState.AdditionalParachains = newTestParachains(otherParaID)
+	require.NoError(t, clientState.VerifyClientMessage(ctx, cdc, clientStore, beefyHeader))
+
+	heights := clientState.UpdateState(ctx, cdc, clientStore, beefyHeader)
+	require.Equal(t, []exported.Height{
+		beefytypes.ParachainHeight(PARA_ID, 10),
+		beefytypes.ParachainHeight(otherParaID, 5),
+		beefytypes.ParachainHeight(otherParaID, 6),
+	}, heights)
+	require.Equal(t, uint32(10), clientState.LatestParaHeight, "only the parachain of the client sets the latest height")
+
+	for _, height := range heights {
+		_, err := beefytypes.GetConsensusState(clientStore, cdc, height)
+		require.NoError(t, err)
+	}
+
+	// consensus states of different parachains are not neighbours
+	_, ok := beefytypes.GetPreviousConsensusState(clientStore, cdc, beefytypes.ParachainHeight(otherParaID, 5))
+	require.False(t, ok)
+	_, ok = beefytypes.GetNextConsensusState(clientStore, cdc, beefytypes.ParachainHeight(PARA_ID, 10))
+	require.False(t, ok)
+}
+
+func TestVerifyParachainHeadersExtrinsicProof(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	authorities := newTestAuthorities(t, 4)
+
+	stateRoot := bytes32(crypto.Keccak256([]byte("state root")))
+	forgedTimestamp, err := hex.DecodeString("280403000bde4660c47e02")
+	require.NoError(t, err)
+	otherHeader := newTestParachainHeaderWithExtrinsic(t, 11, stateRoot, nil, forgedTimestamp)
+
+	testCases := []struct {
+		name     string
+		malleate func(parachainHeader *beefytypes.ParachainHeader)
+		expPass  bool
+	}{
+		{"valid extrinsic proof", func(parachainHeader *beefytypes.ParachainHeader) {}, true},
+		{"missing extrinsic proof", func(parachainHeader *beefytypes.ParachainHeader) {
+			parachainHeader.ExtrinsicProof = nil
+		}, false},
+		{"extrinsic proof of another header", func(parachainHeader *beefytypes.ParachainHeader) {
+			parachainHeader.ExtrinsicProof = otherHeader.ExtrinsicProof
+			parachainHeader.TimestampExtrinsic = otherHeader.TimestampExtrinsic
+		}, false},
+		{"timestamp extrinsic differs from the proven one", func(parachainHeader *beefytypes.ParachainHeader) {
+			parachainHeader.TimestampExtrinsic = forgedTimestamp
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			parachainHeaders := []*beefytypes.ParachainHeader{
+				newTestParachainHeader(t, 10, stateRoot, nil),
+				newTestParachainHeader(t, 11, stateRoot, nil),
+			}
+
+			store := mmr.NewMemStore()
+			mmrTree := mmr.NewMMR(0, store, nil, hasher.Keccak256Hasher{})
+			var positions []uint64
+			for leafIndex := uint32(0); leafIndex < 3; leafIndex++ {
+				leafHash := crypto.Keccak256([]byte{byte(leafIndex)})
+				if leafIndex > 0 {
+					leafHash = newTestMMRLeafHash(t, leafIndex, parachainHeaders[leafIndex-1])
+					positions = append(positions, mmr.LeafIndexToPos(uint64(leafIndex)))
+				}
+				_, err := mmrTree.Push(leafHash)
+				require.NoError(t, err)
+			}
+			mmrTree.Commit()
+			mmrRoot, err := mmrTree.Root()
+			require.NoError(t, err)
+			proof, err := mmrTree.GenProof(positions)
+			require.NoError(t, err)
+
+			// the extrinsic proof isn't part of the mmr leaf, so the header is otherwise valid
+			tc.malleate(parachainHeaders[1])
+
+			beefyHeader := authorities.signedHeader(t, &beefytypes.Commitment{
+				Payload:        []*beefytypes.PayloadItem{{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: mmrRoot}},
+				BlockNumer:     90,
+				ValidatorSetId: 1,
+			}, []uint32{0, 1, 2, 3})
+			beefyHeader.ConsensusStateUpdate = &beefytypes.ConsensusStateUpdateProof{
+				ParachainHeaders: parachainHeaders,
+				MmrProofs:        proof.ProofItems(),
+				MmrSize:          mmrTree.MMRSize(),
+			}
+
+			clientState := &beefytypes.ClientState{
+				LatestBeefyHeight: 100,
+				MmrRootHash:       mmrRoot,
+				ParaId:            PARA_ID,
+				Authority:         authorities.authoritySet(1),
+				NextAuthoritySet:  authorities.authoritySet(2),
+			}
+
+			err = clientState.VerifyClientMessage(ctx, cdc, clientStore, beefyHeader)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, beefytypes.ErrInvalidExtrinsicProof)
+				require.Contains(t, err.Error(), "parachain header 1")
+			}
+		})
+	}
+}
+
+func TestVerifyHeaderAuthoritySetRotation(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	// the size of the authority set changes on every rotation
+	authorities := []testAuthorities[ecdsaTestKey]{nil, newTestAuthorities(t, 7), newTestAuthorities(t, 4), newTestAuthorities(t, 5)}
+	newClientState := func() *beefytypes.ClientState {
+		return &beefytypes.ClientState{
+			LatestBeefyHeight: 100,
+			ParaId:            PARA_ID,
+			Authority:         authorities[1].authoritySet(1),
+			NextAuthoritySet:  authorities[2].authoritySet(2),
+		}
+	}
+	signers := func(n uint32) []uint32 {
+		indices := make([]uint32, n)
+		for i := range indices {
+			indices[i] = uint32(i)
+		}
+		return indices
+	}
+
+	testCases := []struct {
+		name           string
+		validatorSetId uint64
+		signatures     uint32
+		expErr         error
+	}{
+		{"current set without a supermajority", 1, 4, beefytypes.ErrCommitmentNotFinal},
+		{"current set with a supermajority", 1, 5, nil},
+		{"smaller next set with a supermajority", 2, 3, nil},
+		{"smaller next set without a supermajority", 2, 2, beefytypes.ErrCommitmentNotFinal},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			clientState := newClientState()
+			header := newTestRotationHeader(t, authorities[tc.validatorSetId], tc.validatorSetId, signers(tc.signatures), 110, authorities[3].authoritySet(3))
+
+			err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint32(110), clientState.LatestBeefyHeight)
+
+			if tc.validatorSetId == 1 {
+				require.Equal(t, authorities[1].authoritySet(1), clientState.Authority)
+				require.Equal(t, authorities[2].authoritySet(2), clientState.NextAuthoritySet)
+			} else {
+				require.Equal(t, authorities[2].authoritySet(2), clientState.Authority)
+				require.Equal(t, authorities[3].authoritySet(3), clientState.NextAuthoritySet)
+			}
+		})
+	}
+
+	// after the rotation the threshold follows the size of the new sets
+	clientState := newClientState()
+	header := newTestRotationHeader(t, authorities[2], 2, signers(3), 110, authorities[3].authoritySet(3))
+	require.NoError(t, clientState.VerifyClientMessage(ctx, cdc, clientStore, header))
+
+	header = newTestRotationHeader(t, authorities[3], 3, signers(3), 120, authorities[1].authoritySet(4))
+	err := clientState.VerifyClientMessage(ctx, cdc, clientStore, header)
+	require.ErrorIs(t, err, beefytypes.ErrCommitmentNotFinal)
+
+	header = newTestRotationHeader(t, authorities[3], 3, signers(4), 120, authorities[1].authoritySet(4))
+	require.NoError(t, clientState.VerifyClientMessage(ctx, cdc, clientStore, header))
+	require.Equal(t, authorities[3].authoritySet(3), clientState.Authority)
+	require.Equal(t, authorities[1].authoritySet(4), clientState.NextAuthoritySet)
+}
+
+func TestClientSupermajority(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+	authorities := newTestAuthorities(t, 4)
+
+	newClientState := func(supermajority beefytypes.Supermajority) *beefytypes.ClientState {
+		return &beefytypes.ClientState{
+			LatestBeefyHeight: 100,
+			RelayChain:        beefytypes.RelayChain_ROCOCO,
+			ParaId:            PARA_ID,
+			Authority:         authorities.authoritySet(1),
+			NextAuthoritySet:  authorities.authoritySet(2),
+			Supermajority:     supermajority,
+		}
+	}
+	threeQuarters := beefytypes.Supermajority{Numerator: 3, Denominator: 4}
+
+	// 3 of 4 authorities are more than two thirds, but not more than three quarters
+	header := newTestRotationHeader(t, authorities, 1, []uint32{0, 1, 2}, 110, authorities.authoritySet(2))
+	require.NoError(t, newClientState(beefytypes.Supermajority{}).VerifyClientMessage(ctx, cdc, clientStore, header))
+
+	err := newClientState(threeQuarters).VerifyClientMessage(ctx, cdc, clientStore, header)
+	require.ErrorIs(t, err, beefytypes.ErrCommitmentNotFinal)
+
+	header = newTestRotationHeader(t, authorities, 1, []uint32{0, 1, 2, 3}, 110, authorities.authoritySet(2))
+	require.NoError(t, newClientState(threeQuarters).VerifyClientMessage(ctx, cdc, clientStore, header))
+
+	// a supermajority outside of [1/2, 1) fails the validation of the client state
+	for _, supermajority := range []beefytypes.Supermajority{
+		{Numerator: 1, Denominator: 3},
+		{Numerator: 1, Denominator: 1},
+		{Numerator: 1, Denominator: 0},
+	} {
+		clientState := newClientState(supermajority)
+		clientState.MmrRootHash = crypto.Keccak256([]byte("mmr root"))
+		require.ErrorIs(t, clientState.Validate(), beefytypes.ErrInvalidSupermajority)
+	}
+
+	clientState := newClientState(threeQuarters)
+	clientState.MmrRootHash = crypto.Keccak256([]byte("mmr root"))
+	require.NoError(t, clientState.Validate())
+}
+
+// newTestRotationHeader returns a Header whose commitment at the given block number is signed by the given
+// authorities of the set with the given id. The latest mmr leaf of the commitment announces the next authority
+// set, and the header contains a single parachain header proven against the same mmr root.
+func newTestRotationHeader(
+	t *testing.T, authorities testSigners, validatorSetId uint64, signers []uint32, blockNumber uint32,
+	nextAuthoritySet *beefytypes.BeefyAuthoritySet,
+) *beefytypes.Header {
+	t.Helper()
+
+	parachainHeader := newTestParachainHeader(t, 10, bytes32(crypto.Keccak256([]byte("state root"))), nil)
+	parentHash := bytes32(crypto.Keccak256([]byte("parent hash")))
+	parachainHeads := bytes32(crypto.Keccak256([]byte("parachain heads")))
+	latestLeaf := &beefytypes.BeefyMmrLeaf{
+		ParentNumber:          blockNumber - 1,
+		ParentHash:            &parentHash,
+		BeefyNextAuthoritySet: *nextAuthoritySet,
+		ParachainHeads:        &parachainHeads,
+	}
+	latestLeafBytes, err := rpcclienttypes.Encode(latestLeaf)
+	require.NoError(t, err)
+
+	// leaves: an unrelated leaf, the leaf of the parachain header and the latest leaf
+	store := mmr.NewMemStore()
+	mmrTree := mmr.NewMMR(0, store, nil, hasher.Keccak256Hasher{})
+	for _, leafHash := range [][]byte{
+		crypto.Keccak256([]byte{0}),
+		newTestMMRLeafHash(t, 1, parachainHeader),
+		crypto.Keccak256(latestLeafBytes),
+	} {
+		_, err := mmrTree.Push(leafHash)
+		require.NoError(t, err)
+	}
+	mmrTree.Commit()
+	mmrRoot, err := mmrTree.Root()
+	require.NoError(t, err)
+	parachainProof, err := mmrTree.GenProof([]uint64{mmr.LeafIndexToPos(1)})
+	require.NoError(t, err)
+	latestLeafProof, err := mmrTree.GenProof([]uint64{mmr.LeafIndexToPos(2)})
+	require.NoError(t, err)
+
+	header := authorities.signedHeader(t, &beefytypes.Commitment{
+		Payload:        []*beefytypes.PayloadItem{{PayloadId: &beefytypes.SizedByte2{'m', 'h'}, PayloadData: mmrRoot}},
+		BlockNumer:     blockNumber,
+		ValidatorSetId: validatorSetId,
+	}, signers)
+	header.ClientState.MmrLeaf = latestLeaf
+	header.ClientState.MmrLeafIndex = 2
+	header.ClientState.MmrProof = latestLeafProof.ProofItems()
+	header.ConsensusStateUpdate = &beefytypes.ConsensusStateUpdateProof{
+		ParachainHeaders: []*beefytypes.ParachainHeader{parachainHeader},
+		MmrProofs:        parachainProof.ProofItems(),
+		MmrSize:          mmrTree.MMRSize(),
+	}
+
+	return header
+}
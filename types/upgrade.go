@@ -0,0 +1,135 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v5/modules/core/23-commitment/types"
+	"github.com/cosmos/ibc-go/v5/modules/core/exported"
+)
+
+// VerifyUpgradeAndUpdateState checks if the upgraded client has been committed by the current client.
+// The parachain commits the upgraded client and consensus state in its state trie, under the upgrade
+// keys for the latest parachain height known to this client, and we verify both against the root of the
+// latest consensus state.
+// VerifyUpgrade will return an error if:
+//   - the upgradedClient is not a Beefy ClientState
+//   - the upgradedConsState is not a Beefy ConsensusState
+//   - the roots of the consensus states are not parachain state roots, see RootSource
+//   - the upgraded client does not track a higher parachain height, when it follows the same parachain
+//   - the consensus state at the latest parachain height of the client can't be found
+//   - the proofs can't be decoded or don't verify against the root of that consensus state
+//   - the new client state fails basic validation
+//
+// All chain-specified fields of the new client come from the committed client, along with the parachains it
+// tracks, while the frozen height, retention, trusting period and supermajority come from the current client. The sentinel
+// consensus state is stored at the latest height of the new client, which is its latest parachain height and must
+// not hold a consensus state already.
+func (cs *ClientState) VerifyUpgradeAndUpdateState(
+	ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore,
+	upgradedClient exported.ClientState, upgradedConsState exported.ConsensusState,
+	proofUpgradeClient, proofUpgradeConsState []byte,
+) (exported.ClientState, exported.ConsensusState, error) {
+	beefyUpgradeClient, ok := upgradedClient.(*ClientState)
+	if !ok {
+		return nil, nil, sdkerrors.Wrapf(clienttypes.ErrInvalidClientType, "upgraded client must be Beefy client. expected: %T got: %T",
+			&ClientState{}, upgradedClient)
+	}
+	beefyUpgradeConsState, ok := upgradedConsState.(*ConsensusState)
+	if !ok {
+		return nil, nil, sdkerrors.Wrapf(clienttypes.ErrInvalidConsensus, "upgraded consensus state must be Beefy consensus state. expected %T, got: %T",
+			&ConsensusState{}, upgradedConsState)
+	}
+
+	// the upgrade is committed in the parachain state trie, whose root is not known to a client that
+	// stores the root of the ibc child trie in its consensus states
+	if cs.RootSource != RootSource_STATE_ROOT {
+		return nil, nil, sdkerrors.Wrapf(clienttypes.ErrInvalidUpgradeClient, "cannot verify an upgrade committed in the parachain state trie against the %s roots of the client",
+			cs.RootSource)
+	}
+
+	// a change of relay chain or para id restarts the parachain height
+	sameParachain := cs.RelayChain == beefyUpgradeClient.RelayChain && cs.ParaId == beefyUpgradeClient.ParaId
+	if sameParachain && beefyUpgradeClient.LatestParaHeight <= cs.LatestParaHeight {
+		return nil, nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidHeight, "upgraded client parachain height %d must be greater than current client parachain height %d",
+			beefyUpgradeClient.LatestParaHeight, cs.LatestParaHeight)
+	}
+
+	// consensus states are stored at parachain heights, so the last height of the counterparty
+	// is the latest parachain height rather than the latest beefy height.
+	lastHeight := ParachainHeight(cs.ParaId, cs.LatestParaHeight)
+
+	// Must prove against latest consensus state to ensure we are verifying against latest upgrade plan
+	consState, err := GetConsensusState(clientStore, cdc, lastHeight)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "could not retrieve consensus state for lastHeight")
+	}
+
+	// the upgrade is committed in the parachain state trie rather than in the ibc child trie, so the proofs
+	// are verified directly against the state root of the consensus state
+	var proofClient, proofConsState [][]byte
+	if err := rpcclienttypes.Decode(proofUpgradeClient, &proofClient); err != nil {
+		return nil, nil, sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "could not decode client state proof: %v", err)
+	}
+	if err := rpcclienttypes.Decode(proofUpgradeConsState, &proofConsState); err != nil {
+		return nil, nil, sdkerrors.Wrapf(commitmenttypes.ErrInvalidProof, "could not decode consensus state proof: %v", err)
+	}
+
+	// the upgraded states are committed in their protobuf encoding, like any other ibc upgrade
+	bz, err := cdc.MarshalInterface(upgradedClient)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrapf(clienttypes.ErrInvalidClient, "could not marshal client state: %v", err)
+	}
+	upgradeClientKey := upgradetypes.UpgradedClientKey(int64(lastHeight.GetRevisionHeight()))
+	if err := verifyTrieMembership(proofClient, consState.Root, upgradeClientKey, bz); err != nil {
+		return nil, nil, sdkerrors.Wrapf(err, "client state proof failed. Key: %s", upgradeClientKey)
+	}
+
+	bz, err = cdc.MarshalInterface(upgradedConsState)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrapf(clienttypes.ErrInvalidConsensus, "could not marshal consensus state: %v", err)
+	}
+	upgradeConsStateKey := upgradetypes.UpgradedConsStateKey(int64(lastHeight.GetRevisionHeight()))
+	if err := verifyTrieMembership(proofConsState, consState.Root, upgradeConsStateKey, bz); err != nil {
+		return nil, nil, sdkerrors.Wrapf(err, "consensus state proof failed. Key: %s", upgradeConsStateKey)
+	}
+
+	// Relayer chosen client parameters of the upgraded client are ignored in favour of the current ones,
+	// and the client is no longer frozen.
+	newClientState := beefyUpgradeClient.ZeroCustomFields().(*ClientState)
+	newClientState.ConsensusStateRetentionPeriod = cs.ConsensusStateRetentionPeriod
+	newClientState.MaxConsensusStates = cs.MaxConsensusStates
+	newClientState.TrustingPeriod = cs.TrustingPeriod
+	newClientState.Supermajority = cs.Supermajority
+	// the parachains tracked by the upgraded client are committed along with it
+	newClientState.AdditionalParachains = beefyUpgradeClient.AdditionalParachains
+
+	if err := newClientState.Validate(); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "updated client state failed basic validation")
+	}
+
+	// The new consensus state is merely used as a trusted kernel for the upgraded chain, its root
+	// can't be known in advance so it is a sentinel value which no proof will verify against.
+	newConsState := &ConsensusState{
+		Timestamp: beefyUpgradeConsState.Timestamp,
+		Root:      []byte(SentinelRoot),
+	}
+
+	// a consensus state that was verified by the current client must not be replaced by the sentinel
+	newHeight := newClientState.GetLatestHeight()
+	if prevConsState, _ := GetConsensusState(clientStore, cdc, newHeight); prevConsState != nil {
+		return nil, nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidHeight, "a consensus state is already stored at the upgraded client height %s", newHeight)
+	}
+
+	// only write to the client store once every check has passed. 02-client replaces the client state and
+	// stores the consensus state we return at the latest height of the new client, which is newHeight, so
+	// the sentinel is only stored at that height, along with its metadata.
+	setConsensusState(clientStore, cdc, newConsState, newHeight)
+	setConsensusMetadata(ctx, clientStore, newHeight)
+
+	return newClientState, newConsState, nil
+}
@@ -0,0 +1,221 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	rpcclienttypes "github.com/ComposableFi/go-substrate-rpc-client/v4/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	clienttypes "github.com/cosmos/ibc-go/v5/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v5/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v5/modules/core/24-host"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	beefytypes "github.com/ComposableFi/ics11-beefy/types"
+)
+
+func TestVerifyUpgradeAndUpdateState(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	clientState := &beefytypes.ClientState{
+		LatestBeefyHeight:    10,
+		LatestParaHeight:     20,
+		ParaId:               PARA_ID,
+		AdditionalParachains: newTestParachains(PARA_ID + 1),
+		Authority:            &beefytypes.BeefyAuthoritySet{Id: 1, Len: 5},
+		NextAuthoritySet:     &beefytypes.BeefyAuthoritySet{Id: 2, Len: 5},
+		TrustingPeriod:       2 * time.Hour,
+		Supermajority:        beefytypes.Supermajority{Numerator: 3, Denominator: 4},
+	}
+	authorityRoot := bytes32(crypto.Keccak256([]byte("authority root")))
+	upgradedClient := &beefytypes.ClientState{
+		LatestBeefyHeight:    30,
+		LatestParaHeight:     1,
+		ParaId:               PARA_ID + 1,
+		AdditionalParachains: newTestParachains(PARA_ID + 3),
+		CommitmentPrefix:     []byte("ibc/"),
+		MmrRootHash:          crypto.Keccak256([]byte("mmr root")),
+		Authority:            &beefytypes.BeefyAuthoritySet{Id: 3, Len: 7, AuthorityRoot: &authorityRoot},
+		NextAuthoritySet:     &beefytypes.BeefyAuthoritySet{Id: 4, Len: 7, AuthorityRoot: &authorityRoot},
+		// relayer chosen fields are dropped
+		FrozenHeight:   5,
+		TrustingPeriod: time.Hour,
+		Supermajority:  beefytypes.Supermajority{Numerator: 4, Denominator: 5},
+	}
+	upgradedConsState := &beefytypes.ConsensusState{
+		Timestamp: time.Unix(1643972151, 0).UTC(),
+		Root:      []byte("upgraded root"),
+	}
+
+	upgradedClientBz, err := cdc.MarshalInterface(upgradedClient)
+	require.NoError(t, err)
+	upgradedConsStateBz, err := cdc.MarshalInterface(upgradedConsState)
+	require.NoError(t, err)
+
+	clientKey := upgradetypes.UpgradedClientKey(int64(clientState.LatestParaHeight))
+	consStateKey := upgradetypes.UpgradedConsStateKey(int64(clientState.LatestParaHeight))
+	root, proofs := newTestTrieProofs(t, map[string][]byte{
+		string(clientKey):    upgradedClientBz,
+		string(consStateKey): upgradedConsStateBz,
+		"unrelated key":      []byte("unrelated value"),
+	}, clientKey, consStateKey)
+
+	// there is no consensus state at the latest parachain height yet
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, proofs[0], proofs[1])
+	require.ErrorIs(t, err, clienttypes.ErrConsensusStateNotFound)
+
+	lastHeight := beefytypes.ParachainHeight(clientState.ParaId, clientState.LatestParaHeight)
+	consState := &beefytypes.ConsensusState{Timestamp: time.Unix(1643972100, 0), Root: root}
+	clientStore.Set(host.ConsensusStateKey(lastHeight), clienttypes.MustMarshalConsensusState(cdc, consState))
+
+	// the proofs are not interchangeable
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, proofs[1], proofs[0])
+	require.ErrorIs(t, err, commitmenttypes.ErrInvalidProof)
+
+	// the committed client can't be swapped
+	tampered := *upgradedClient
+	tampered.ParaId = PARA_ID + 2
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, &tampered, upgradedConsState, proofs[0], proofs[1])
+	require.ErrorIs(t, err, commitmenttypes.ErrInvalidProof)
+
+	// the consensus state of a tracked parachain at the upgraded height can't be replaced by the sentinel
+	upgradedHeight := beefytypes.ParachainHeight(upgradedClient.ParaId, upgradedClient.LatestParaHeight)
+	clientStore.Set(host.ConsensusStateKey(upgradedHeight), clienttypes.MustMarshalConsensusState(cdc, consState))
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, proofs[0], proofs[1])
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidHeight)
+	storedConsState, err := beefytypes.GetConsensusState(clientStore, cdc, upgradedHeight)
+	require.NoError(t, err)
+	require.Equal(t, consState.Root, storedConsState.Root)
+	clientStore.Delete(host.ConsensusStateKey(upgradedHeight))
+
+	newClient, newConsState, err := clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, proofs[0], proofs[1])
+	require.NoError(t, err)
+
+	newClientState, ok := newClient.(*beefytypes.ClientState)
+	require.True(t, ok)
+	require.Zero(t, newClientState.FrozenHeight)
+	require.Equal(t, clientState.TrustingPeriod, newClientState.TrustingPeriod)
+	require.Equal(t, clientState.Supermajority, newClientState.Supermajority)
+	require.Equal(t, upgradedClient.ParaId, newClientState.ParaId)
+	require.Equal(t, upgradedClient.AdditionalParachains, newClientState.AdditionalParachains)
+	require.Equal(t, upgradedClient.CommitmentPrefix, newClientState.CommitmentPrefix)
+	require.Equal(t, upgradedClient.LatestBeefyHeight, newClientState.LatestBeefyHeight)
+	require.Equal(t, upgradedClient.MmrRootHash, newClientState.MmrRootHash)
+	require.Equal(t, upgradedClient.Authority, newClientState.Authority)
+	require.Equal(t, upgradedClient.NextAuthoritySet, newClientState.NextAuthoritySet)
+
+	require.Equal(t, upgradedConsState.GetTimestamp(), newConsState.GetTimestamp())
+	require.Equal(t, []byte(beefytypes.SentinelRoot), newConsState.(*beefytypes.ConsensusState).Root)
+
+	// 02-client stores the returned consensus state at the latest height of the new client, which is where
+	// the sentinel and its metadata are stored
+	require.Equal(t, upgradedHeight, newClient.GetLatestHeight())
+	storedConsState, err = beefytypes.GetConsensusState(clientStore, cdc, upgradedHeight)
+	require.NoError(t, err)
+	require.Equal(t, newConsState, storedConsState)
+	_, err = beefytypes.GetProcessedTime(clientStore, upgradedHeight)
+	require.NoError(t, err)
+}
+
+func TestVerifyUpgradeAndUpdateStateHeight(t *testing.T) {
+	ctx, cdc, clientStore := newTestClientStore(t)
+
+	clientState := &beefytypes.ClientState{LatestBeefyHeight: 10, LatestParaHeight: 20, ParaId: PARA_ID}
+	upgradedClient := &beefytypes.ClientState{LatestBeefyHeight: 30, LatestParaHeight: 20, ParaId: PARA_ID}
+
+	// the same parachain must be upgraded at a higher height
+	_, _, err := clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, &beefytypes.ConsensusState{}, nil, nil)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidHeight)
+
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, &beefytypes.ClientState{}, nil, nil, nil)
+	require.ErrorIs(t, err, clienttypes.ErrInvalidConsensus)
+}
+
+func TestVerifyUpgradeAndUpdateStateRootSource(t *testing.T) {
+	authorityRoot := bytes32(crypto.Keccak256([]byte("authority root")))
+
+	testCases := []struct {
+		rootSource beefytypes.RootSource
+		expErr     error
+	}{
+		{beefytypes.RootSource_STATE_ROOT, nil},
+		// the root of the ibc child trie can't prove an upgrade committed in the parachain state trie
+		{beefytypes.RootSource_IBC_DIGEST, clienttypes.ErrInvalidUpgradeClient},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.rootSource.String(), func(t *testing.T) {
+			ctx, cdc, clientStore := newTestClientStore(t)
+
+			clientState := &beefytypes.ClientState{LatestBeefyHeight: 10, LatestParaHeight: 20, ParaId: PARA_ID, RootSource: tc.rootSource}
+			upgradedClient := &beefytypes.ClientState{
+				LatestBeefyHeight: 30,
+				LatestParaHeight:  21,
+				ParaId:            PARA_ID,
+				MmrRootHash:       crypto.Keccak256([]byte("mmr root")),
+				Authority:         &beefytypes.BeefyAuthoritySet{Id: 3, Len: 7, AuthorityRoot: &authorityRoot},
+				NextAuthoritySet:  &beefytypes.BeefyAuthoritySet{Id: 4, Len: 7, AuthorityRoot: &authorityRoot},
+				RootSource:        tc.rootSource,
+			}
+			upgradedConsState := &beefytypes.ConsensusState{Timestamp: time.Unix(1643972151, 0).UTC()}
+
+			upgradedClientBz, err := cdc.MarshalInterface(upgradedClient)
+			require.NoError(t, err)
+			upgradedConsStateBz, err := cdc.MarshalInterface(upgradedConsState)
+			require.NoError(t, err)
+
+			clientKey := upgradetypes.UpgradedClientKey(int64(clientState.LatestParaHeight))
+			consStateKey := upgradetypes.UpgradedConsStateKey(int64(clientState.LatestParaHeight))
+			root, proofs := newTestTrieProofs(t, map[string][]byte{
+				string(clientKey):    upgradedClientBz,
+				string(consStateKey): upgradedConsStateBz,
+			}, clientKey, consStateKey)
+
+			lastHeight := beefytypes.ParachainHeight(clientState.ParaId, clientState.LatestParaHeight)
+			consState := &beefytypes.ConsensusState{Timestamp: time.Unix(1643972100, 0), Root: root}
+			clientStore.Set(host.ConsensusStateKey(lastHeight), clienttypes.MustMarshalConsensusState(cdc, consState))
+
+			_, _, err = clientState.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, proofs[0], proofs[1])
+			if tc.expErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+// newTestTrieProofs builds a substrate trie from the given entries and returns its root,
+// along with a scale-encoded proof for each of the given keys.
+func newTestTrieProofs(t *testing.T, entries map[string][]byte, keys ...[]byte) ([]byte, [][]byte) {
+	t.Helper()
+
+	tr := trie.NewEmptyTrie()
+	for key, value := range entries {
+		tr.Put([]byte(key), value)
+	}
+	root, err := tr.Hash()
+	require.NoError(t, err)
+
+	db, err := chaindb.NewBadgerDB(&chaindb.Config{InMemory: true, DataDir: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, tr.Store(db))
+
+	var proofs [][]byte
+	for _, key := range keys {
+		proof, err := trie.GenerateProof(root.ToBytes(), [][]byte{key}, db)
+		require.NoError(t, err)
+
+		proofBz, err := rpcclienttypes.Encode(proof)
+		require.NoError(t, err)
+		proofs = append(proofs, proofBz)
+	}
+
+	return root.ToBytes(), proofs
+}